@@ -3,6 +3,7 @@ package elasticsearchreceiver
 import (
 	"fmt"
 	"net/url"
+	"path"
 	"strings"
 
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -15,6 +16,43 @@ type Config struct {
 
 	Password string `mapstructure:"password"`
 	Username string `mapstructure:"username"`
+	// APIKey is an API key, either in the "id:api_key" form or base64 encoded as returned by the create API key API.
+	APIKey string `mapstructure:"api_key"`
+	// BearerToken is sent as a bearer token, such as an access token or a service account token.
+	BearerToken string `mapstructure:"bearer_token"`
+
+	// Nodes selects the nodes that node stats are collected from, with node filters such as "_local"
+	// or "_all". All nodes are scraped if it is empty.
+	Nodes []string `mapstructure:"nodes"`
+	// SkipClusterMetrics disables the collection of cluster-level metrics, so that only one of several
+	// receivers scraping the same cluster reports them. The index, snapshot and index lifecycle metrics
+	// are cluster-level too, so they cannot be enabled along with it.
+	SkipClusterMetrics bool `mapstructure:"skip_cluster_metrics"`
+
+	// Indices configures the collection of per-index statistics.
+	Indices IndicesConfig `mapstructure:"indices"`
+	// Snapshots configures the collection of snapshot repository and snapshot lifecycle metrics.
+	Snapshots SnapshotsConfig `mapstructure:"snapshots"`
+	// ILM configures the collection of index lifecycle metrics.
+	ILM ILMConfig `mapstructure:"ilm"`
+}
+
+// SnapshotsConfig enables the snapshot repository and snapshot lifecycle management (SLM) metrics.
+type SnapshotsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ILMConfig enables the index lifecycle management (ILM) metrics.
+type ILMConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// IndicesConfig selects the indices that statistics are collected for with glob patterns. An index is
+// selected if it matches any include pattern, or there are none, and does not match any exclude pattern.
+type IndicesConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
 }
 
 var (
@@ -29,6 +67,24 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if err := cfg.multipleAuthMethods(); err != nil {
+		return err
+	}
+
+	for _, node := range cfg.Nodes {
+		if node == "" {
+			return fmt.Errorf("'nodes' must not contain empty node filters")
+		}
+	}
+
+	if err := cfg.Indices.validate(); err != nil {
+		return err
+	}
+
+	if cfg.SkipClusterMetrics && (cfg.Indices.Enabled || cfg.Snapshots.Enabled || cfg.ILM.Enabled) {
+		return fmt.Errorf("'indices', 'snapshots' and 'ilm' cannot be enabled with 'skip_cluster_metrics'")
+	}
+
 	if cfg.Endpoint == "" {
 		cfg.Endpoint = DefaultEndpoint
 		return nil
@@ -55,6 +111,115 @@ func (cfg *Config) Validate() error {
 	return nil
 }
 
+// nodeStatsPath returns the node stats path for the configured node filters.
+func (cfg *Config) nodeStatsPath() string {
+	return cfg.nodesPath("/stats")
+}
+
+// nodeInfoPath returns the node info path for the configured node filters, limited to the node versions.
+func (cfg *Config) nodeInfoPath() string {
+	return cfg.nodesPath("?filter_path=nodes.*.version")
+}
+
+func (cfg *Config) nodesPath(suffix string) string {
+	if len(cfg.Nodes) == 0 {
+		return "/_nodes" + suffix
+	}
+	filters := make([]string, 0, len(cfg.Nodes))
+	for _, node := range cfg.Nodes {
+		filters = append(filters, url.PathEscape(node))
+	}
+	return fmt.Sprintf("/_nodes/%s%s", strings.Join(filters, ","), suffix)
+}
+
+// indexStatsPath returns the index stats path for the configured indices, limited to the sections that are reported.
+func (cfg *Config) indexStatsPath() string {
+	return fmt.Sprintf("/%s/_stats/docs,store,indexing,get,search,segments?level=indices&ignore_unavailable=true", cfg.Indices.target())
+}
+
+// catIndicesPath returns the cat indices path for the configured indices, limited to their shard counts.
+func (cfg *Config) catIndicesPath() string {
+	return fmt.Sprintf("/_cat/indices/%s?format=json&h=index,pri,rep&ignore_unavailable=true", cfg.Indices.target())
+}
+
+// target returns the configured indices in the multi-target syntax, so that only their stats are requested
+// rather than those of every index in the cluster. Patterns using glob syntax that Elasticsearch does
+// not support are left to matches instead.
+func (ic IndicesConfig) target() string {
+	includes := make([]string, 0, len(ic.Include))
+	for _, pattern := range ic.Include {
+		if !isIndexExpression(pattern) {
+			includes = []string{"*"}
+			break
+		}
+		includes = append(includes, pattern)
+	}
+	if len(includes) == 0 {
+		includes = []string{"*"}
+	}
+
+	targets := includes
+	for _, pattern := range ic.Exclude {
+		if isIndexExpression(pattern) {
+			targets = append(targets, "-"+pattern)
+		}
+	}
+	for i, target := range targets {
+		targets[i] = url.PathEscape(target)
+	}
+	return strings.Join(targets, ",")
+}
+
+// isIndexExpression reports whether a glob pattern means the same to Elasticsearch, which only supports the * wildcard.
+func isIndexExpression(pattern string) bool {
+	return pattern != "" && !strings.ContainsAny(pattern, `?[\,/ `)
+}
+
+func (ic IndicesConfig) validate() error {
+	for _, pattern := range append(ic.Include, ic.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid index pattern '%s'", pattern)
+		}
+	}
+	return nil
+}
+
+func (ic IndicesConfig) matches(index string) bool {
+	if len(ic.Include) > 0 && !matchesAny(ic.Include, index) {
+		return false
+	}
+	return !matchesAny(ic.Exclude, index)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// multipleAuthMethods returns an error if more than one way of authenticating is configured,
+// as each of them sets the Authorization header.
+func (cfg *Config) multipleAuthMethods() error {
+	methods := 0
+	for _, configured := range []bool{
+		cfg.Username != "",
+		cfg.APIKey != "",
+		cfg.BearerToken != "",
+		cfg.Auth != nil,
+	} {
+		if configured {
+			methods++
+		}
+	}
+	if methods > 1 {
+		return fmt.Errorf("only one of 'username', 'api_key', 'bearer_token' or 'auth' can be specified")
+	}
+	return nil
+}
+
 // missingProtocol returns true if any http protocol is found, case sensitive.
 func missingProtocol(rawUrl string) bool {
 	return !strings.HasPrefix(strings.ToLower(rawUrl), "http")
@@ -19,6 +19,19 @@ func TestValidate(t *testing.T) {
 		cfg.Endpoint = "http://endpoint with space"
 		require.Equal(t, errors.New("invalid endpoint 'http://endpoint with space'"), cfg.Validate())
 	})
+	t.Run("error path, multiple auth methods", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.Endpoint = "http://localhost:9200"
+		cfg.APIKey = "id:key"
+		cfg.BearerToken = "token"
+		require.Equal(t, errors.New("only one of 'username', 'api_key', 'bearer_token' or 'auth' can be specified"), cfg.Validate())
+	})
+	t.Run("error path, cluster metrics skipped", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.SkipClusterMetrics = true
+		cfg.Snapshots.Enabled = true
+		require.Equal(t, errors.New("'indices', 'snapshots' and 'ilm' cannot be enabled with 'skip_cluster_metrics'"), cfg.Validate())
+	})
 
 	t.Run("happy path", func(t *testing.T) {
 		testCases := []struct {
@@ -181,3 +194,124 @@ func TestInvalidCredentials(t *testing.T) {
 		})
 	}
 }
+
+func TestIndicesConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		indices  IndicesConfig
+		index    string
+		expected bool
+	}{
+		{
+			desc:     "no patterns",
+			indices:  IndicesConfig{},
+			index:    "orders",
+			expected: true,
+		},
+		{
+			desc:     "default excludes hidden indices",
+			indices:  NewFactory().CreateDefaultConfig().(*Config).Indices,
+			index:    ".kibana_1",
+			expected: false,
+		},
+		{
+			desc:     "included",
+			indices:  IndicesConfig{Include: []string{"logs-*"}},
+			index:    "logs-2021.11.02",
+			expected: true,
+		},
+		{
+			desc:     "not included",
+			indices:  IndicesConfig{Include: []string{"logs-*"}},
+			index:    "orders",
+			expected: false,
+		},
+		{
+			desc:     "included and excluded",
+			indices:  IndicesConfig{Include: []string{"logs-*"}, Exclude: []string{"logs-2021.*"}},
+			index:    "logs-2021.11.02",
+			expected: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			require.Equal(t, tC.expected, tC.indices.matches(tC.index))
+		})
+	}
+}
+
+func TestValidateIndexPatterns(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Indices.Include = []string{"logs-["}
+	require.EqualError(t, cfg.Validate(), "invalid index pattern 'logs-['")
+}
+
+func TestNodeStatsPath(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		nodes        []string
+		expected     string
+		expectedInfo string
+	}{
+		{
+			desc:         "all nodes by default",
+			nodes:        nil,
+			expected:     "/_nodes/stats",
+			expectedInfo: "/_nodes?filter_path=nodes.*.version",
+		},
+		{
+			desc:         "local node",
+			nodes:        []string{"_local"},
+			expected:     "/_nodes/_local/stats",
+			expectedInfo: "/_nodes/_local?filter_path=nodes.*.version",
+		},
+		{
+			desc:         "node filters",
+			nodes:        []string{"master:true", "data-*"},
+			expected:     "/_nodes/master:true,data-%2A/stats",
+			expectedInfo: "/_nodes/master:true,data-%2A?filter_path=nodes.*.version",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := Config{Nodes: tC.nodes}
+			require.Equal(t, tC.expected, cfg.nodeStatsPath())
+			require.Equal(t, tC.expectedInfo, cfg.nodeInfoPath())
+		})
+	}
+}
+
+func TestIndexPaths(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		indices         IndicesConfig
+		expectedStats   string
+		expectedIndices string
+	}{
+		{
+			desc:            "all indices",
+			indices:         IndicesConfig{},
+			expectedStats:   "/%2A/_stats/docs,store,indexing,get,search,segments?level=indices&ignore_unavailable=true",
+			expectedIndices: "/_cat/indices/%2A?format=json&h=index,pri,rep&ignore_unavailable=true",
+		},
+		{
+			desc:            "include and exclude",
+			indices:         IndicesConfig{Include: []string{"logs-*", "metrics"}, Exclude: []string{".*", "logs-debug-*"}},
+			expectedStats:   "/logs-%2A,metrics,-.%2A,-logs-debug-%2A/_stats/docs,store,indexing,get,search,segments?level=indices&ignore_unavailable=true",
+			expectedIndices: "/_cat/indices/logs-%2A,metrics,-.%2A,-logs-debug-%2A?format=json&h=index,pri,rep&ignore_unavailable=true",
+		},
+		{
+			desc:            "glob syntax left to the receiver",
+			indices:         IndicesConfig{Include: []string{"logs-?", "metrics"}, Exclude: []string{"tmp-[0-9]", ".*"}},
+			expectedStats:   "/%2A,-.%2A/_stats/docs,store,indexing,get,search,segments?level=indices&ignore_unavailable=true",
+			expectedIndices: "/_cat/indices/%2A,-.%2A?format=json&h=index,pri,rep&ignore_unavailable=true",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := Config{Indices: tC.indices}
+			require.Equal(t, tC.expectedStats, cfg.indexStatsPath())
+			require.Equal(t, tC.expectedIndices, cfg.catIndicesPath())
+		})
+	}
+}
@@ -36,6 +36,10 @@ func createDefaultConfig() config.Receiver {
 			Endpoint: "localhost:9200",
 			Timeout:  10 * time.Second,
 		},
+		Indices: IndicesConfig{
+			// hidden and system indices
+			Exclude: []string{".*"},
+		},
 	}
 }
 
@@ -0,0 +1,109 @@
+package elasticsearchreceiver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/observiq/opentelemetry-components/receiver/elasticsearchreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// indexMetricsCount is the number of metrics missing if the index stats cannot be read.
+const indexMetricsCount = 6
+
+// scrapeIndexMetrics reports the stats of each index selected by the indices config.
+func (r *elasticsearchScraper) scrapeIndexMetrics(ctx context.Context, ms pdata.MetricSlice) error {
+	indexStats, err := r.makeRequest(ctx, r.cfg.indexStatsPath())
+	if err != nil {
+		return err
+	}
+	indices, ok := indexStats["indices"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("no indices data available")
+	}
+
+	documentsMetric := initMetric(ms, metadata.M.ElasticsearchIndexDocuments).Gauge().DataPoints()
+	storageSizeMetric := initMetric(ms, metadata.M.ElasticsearchIndexStorageSize).Gauge().DataPoints()
+	shardsMetric := initMetric(ms, metadata.M.ElasticsearchIndexShards).Gauge().DataPoints()
+	operationsMetric := initMetric(ms, metadata.M.ElasticsearchIndexOperations).Sum().DataPoints()
+	operationTimeMetric := initMetric(ms, metadata.M.ElasticsearchIndexOperationTime).Sum().DataPoints()
+	segmentsMetric := initMetric(ms, metadata.M.ElasticsearchIndexSegments).Gauge().DataPoints()
+
+	for indexName, indexInter := range indices {
+		if !r.cfg.Indices.matches(indexName) {
+			continue
+		}
+		indexData, ok := indexInter.(map[string]interface{})
+		if !ok {
+			r.logger.Error("could not reflect index data as a map")
+			continue
+		}
+
+		attributes := pdata.NewAttributeMap()
+		attributes.Upsert(metadata.A.IndexName, pdata.NewAttributeValueString(indexName))
+
+		// documents are counted on the primaries only, as replicas hold copies of the same documents
+		attributes.Upsert(metadata.A.DocumentType, pdata.NewAttributeValueString("live"))
+		r.processIntMetric([]string{"primaries", "docs", "count"}, indexData, documentsMetric, attributes)
+		attributes.Upsert(metadata.A.DocumentType, pdata.NewAttributeValueString("deleted"))
+		r.processIntMetric([]string{"primaries", "docs", "deleted"}, indexData, documentsMetric, attributes)
+		attributes.Delete(metadata.A.DocumentType)
+
+		r.processIntMetric([]string{"total", "store", "size_in_bytes"}, indexData, storageSizeMetric, attributes)
+
+		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("index"))
+		r.processIntMetric([]string{"total", "indexing", "index_total"}, indexData, operationsMetric, attributes)
+		r.processIntMetric([]string{"total", "indexing", "index_time_in_millis"}, indexData, operationTimeMetric, attributes)
+		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("delete"))
+		r.processIntMetric([]string{"total", "indexing", "delete_total"}, indexData, operationsMetric, attributes)
+		r.processIntMetric([]string{"total", "indexing", "delete_time_in_millis"}, indexData, operationTimeMetric, attributes)
+		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("get"))
+		r.processIntMetric([]string{"total", "get", "total"}, indexData, operationsMetric, attributes)
+		r.processIntMetric([]string{"total", "get", "time_in_millis"}, indexData, operationTimeMetric, attributes)
+		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("query"))
+		r.processIntMetric([]string{"total", "search", "query_total"}, indexData, operationsMetric, attributes)
+		r.processIntMetric([]string{"total", "search", "query_time_in_millis"}, indexData, operationTimeMetric, attributes)
+		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("fetch"))
+		r.processIntMetric([]string{"total", "search", "fetch_total"}, indexData, operationsMetric, attributes)
+		r.processIntMetric([]string{"total", "search", "fetch_time_in_millis"}, indexData, operationTimeMetric, attributes)
+		attributes.Delete(metadata.A.Operation)
+
+		r.processIntMetric([]string{"total", "segments", "count"}, indexData, segmentsMetric, attributes)
+	}
+
+	catIndices, err := r.makeArrayRequest(ctx, r.cfg.catIndicesPath())
+	if err != nil {
+		return err
+	}
+	for _, catIndex := range catIndices {
+		indexName, err := getStringFromBody([]string{"index"}, catIndex)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+		if !r.cfg.Indices.matches(indexName) {
+			continue
+		}
+
+		primaries, err := getIntFromBody([]string{"pri"}, catIndex)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+		replicas, err := getIntFromBody([]string{"rep"}, catIndex)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+
+		attributes := pdata.NewAttributeMap()
+		attributes.Upsert(metadata.A.IndexName, pdata.NewAttributeValueString(indexName))
+		attributes.Upsert(metadata.A.ShardRole, pdata.NewAttributeValueString(metadata.AttributeShardRole.Primary))
+		addToIntMetric(shardsMetric, attributes, primaries, r.now)
+		// rep is the number of replicas of each primary shard
+		attributes.Upsert(metadata.A.ShardRole, pdata.NewAttributeValueString(metadata.AttributeShardRole.Replica))
+		addToIntMetric(shardsMetric, attributes, primaries*replicas, r.now)
+	}
+
+	return nil
+}
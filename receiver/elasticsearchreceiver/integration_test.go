@@ -42,13 +42,10 @@ func TestElasticSearch7_8(t *testing.T) {
 	}, 2*time.Minute, 1*time.Second, "failed to receive more than 0 metrics")
 
 	md := consumer.AllMetrics()[0]
-	require.Equal(t, 1, md.ResourceMetrics().Len())
-	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
-	require.Equal(t, 1, ilms.Len())
-	metrics := ilms.At(0).Metrics()
+	metrics := collectMetrics(t, md, distributionElasticsearch, "7.8.1")
 	require.NoError(t, rcvr.Shutdown(context.Background()))
 
-	validateResult(t, metrics)
+	validateResult(t, metrics, false)
 }
 
 func TestElasticSearch7_13(t *testing.T) {
@@ -73,13 +70,38 @@ func TestElasticSearch7_13(t *testing.T) {
 	}, 2*time.Minute, 1*time.Second, "failed to receive more than 0 metrics")
 
 	md := consumer.AllMetrics()[0]
-	require.Equal(t, 1, md.ResourceMetrics().Len())
-	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
-	require.Equal(t, 1, ilms.Len())
-	metrics := ilms.At(0).Metrics()
+	metrics := collectMetrics(t, md, distributionElasticsearch, "7.13.3")
 	require.NoError(t, rcvr.Shutdown(context.Background()))
 
-	validateResult(t, metrics)
+	validateResult(t, metrics, true)
+}
+
+func TestOpenSearch2_11(t *testing.T) {
+	container := getContainer(t, containerRequestOpenSearch2_11)
+	defer func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	}()
+	hostname, err := container.Host(context.Background())
+	require.NoError(t, err)
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = fmt.Sprintf("http://%s", net.JoinHostPort(hostname, "9200"))
+
+	consumer := new(consumertest.MetricsSink)
+	settings := componenttest.NewNopReceiverCreateSettings()
+	rcvr, err := f.CreateMetricsReceiver(context.Background(), settings, cfg, consumer)
+	require.NoError(t, err, "failed creating metrics receiver")
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventuallyf(t, func() bool {
+		return len(consumer.AllMetrics()) > 0
+	}, 2*time.Minute, 1*time.Second, "failed to receive more than 0 metrics")
+
+	md := consumer.AllMetrics()[0]
+	metrics := collectMetrics(t, md, distributionOpenSearch, "2.11.0")
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	validateResult(t, metrics, true)
 }
 
 var (
@@ -101,6 +123,15 @@ var (
 		WaitingFor: wait.ForListeningPort("9200").
 			WithStartupTimeout(2 * time.Minute),
 	}
+	containerRequestOpenSearch2_11 = testcontainers.ContainerRequest{
+		FromDockerfile: testcontainers.FromDockerfile{
+			Context:    path.Join(".", "testdata"),
+			Dockerfile: "Dockerfile.opensearch_2.11",
+		},
+		ExposedPorts: []string{"9200:9200"},
+		WaitingFor: wait.ForListeningPort("9200").
+			WithStartupTimeout(2 * time.Minute),
+	}
 )
 
 func getContainer(t *testing.T, req testcontainers.ContainerRequest) testcontainers.Container {
@@ -116,13 +147,75 @@ func getContainer(t *testing.T, req testcontainers.ContainerRequest) testcontain
 	return container
 }
 
-func validateResult(t *testing.T, metrics pdata.MetricSlice) {
-	require.Equal(t, len(metadata.M.Names()), metrics.Len())
+// unreportedMetrics are opt-in metrics that are not reported by the default config.
+var unreportedMetrics = []string{
+	metadata.M.ElasticsearchIndexDocuments.Name(),
+	metadata.M.ElasticsearchIndexOperationTime.Name(),
+	metadata.M.ElasticsearchIndexOperations.Name(),
+	metadata.M.ElasticsearchIndexSegments.Name(),
+	metadata.M.ElasticsearchIndexShards.Name(),
+	metadata.M.ElasticsearchIndexStorageSize.Name(),
+	metadata.M.ElasticsearchSnapshotRepositories.Name(),
+	metadata.M.ElasticsearchSnapshotRepositorySnapshots.Name(),
+	metadata.M.ElasticsearchSnapshotRepositoryLastSuccess.Name(),
+	metadata.M.ElasticsearchSnapshotPolicySnapshots.Name(),
+	metadata.M.ElasticsearchSnapshotPolicyLastSuccess.Name(),
+	metadata.M.ElasticsearchSnapshotPolicyLastFailure.Name(),
+	metadata.M.ElasticsearchIlmIndices.Name(),
+	metadata.M.ElasticsearchIlmFailedIndices.Name(),
+}
+
+// collectMetrics checks the resources of the single node and of the cluster, and returns the metrics of both.
+func collectMetrics(t *testing.T, md pdata.Metrics, distribution, version string) pdata.MetricSlice {
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+
+	nodeAttributes := md.ResourceMetrics().At(0).Resource().Attributes()
+	nodeVersion, ok := nodeAttributes.Get(nodeVersionResourceAttribute)
+	require.True(t, ok)
+	require.Equal(t, version, nodeVersion.StringVal())
+	nodeDistribution, ok := nodeAttributes.Get(distributionResourceAttribute)
+	require.True(t, ok)
+	require.Equal(t, distribution, nodeDistribution.StringVal())
+	for _, attribute := range []string{clusterNameResourceAttribute, nodeIDResourceAttribute, nodeNameResourceAttribute, nodeHostResourceAttribute, nodeRolesResourceAttribute} {
+		_, ok := nodeAttributes.Get(attribute)
+		require.True(t, ok, attribute)
+	}
+	clusterAttributes := md.ResourceMetrics().At(1).Resource().Attributes()
+	require.Equal(t, map[string]interface{}{
+		distributionResourceAttribute: distribution,
+		clusterNameResourceAttribute:  "docker-cluster",
+	}, clusterAttributes.AsRaw())
+
+	metrics := pdata.NewMetricSlice()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		ilms := md.ResourceMetrics().At(i).InstrumentationLibraryMetrics()
+		require.Equal(t, 1, ilms.Len())
+		for j := 0; j < ilms.At(0).Metrics().Len(); j++ {
+			ilms.At(0).Metrics().At(j).CopyTo(metrics.AppendEmpty())
+		}
+	}
+	return metrics
+}
+
+// indexingPressureKeys are only reported from Elasticsearch 7.9.
+var indexingPressureKeys = []string{
+	"elasticsearch.indexing_pressure.memory coordinating",
+	"elasticsearch.indexing_pressure.memory primary",
+	"elasticsearch.indexing_pressure.memory replica",
+	"elasticsearch.indexing_pressure.memory.limit",
+	"elasticsearch.indexing_pressure.rejections coordinating",
+	"elasticsearch.indexing_pressure.rejections primary",
+	"elasticsearch.indexing_pressure.rejections replica",
+}
+
+func validateResult(t *testing.T, metrics pdata.MetricSlice, indexingPressure bool) {
+	require.Equal(t, len(metadata.M.Names())-len(unreportedMetrics), metrics.Len())
 	exists := make(map[string]bool)
 
 	unenumAttributeSet := []string{
-		metadata.A.ServerName,
 		metadata.A.ThreadPoolName,
+		metadata.A.CircuitBreakerName,
+		metadata.A.MemoryPoolName,
 	}
 
 	enumAttributeSet := []string{
@@ -133,11 +226,15 @@ func validateResult(t *testing.T, metrics pdata.MetricSlice) {
 		metadata.A.ShardType,
 		metadata.A.MemoryType,
 		metadata.A.Operation,
+		metadata.A.HealthStatus,
+		metadata.A.TaskPriority,
+		metadata.A.IndexingPressureStage,
 	}
 
 	for i := 0; i < metrics.Len(); i++ {
 		m := metrics.At(i)
 		require.Contains(t, metadata.M.Names(), m.Name())
+		require.NotContains(t, unreportedMetrics, m.Name())
 
 		metricIntr := metadata.M.ByName(m.Name())
 		require.Equal(t, metricIntr.New().DataType(), m.DataType())
@@ -169,49 +266,73 @@ func validateResult(t *testing.T, metrics pdata.MetricSlice) {
 			exists[key] = true
 		}
 	}
-	require.Equal(t, map[string]bool{
-		"elasticsearch.cache_memory_usage server_name field":               true,
-		"elasticsearch.cache_memory_usage server_name query":               true,
-		"elasticsearch.cache_memory_usage server_name request":             true,
-		"elasticsearch.evictions server_name field":                        true,
-		"elasticsearch.evictions server_name query":                        true,
-		"elasticsearch.evictions server_name request":                      true,
-		"elasticsearch.gc_collection server_name young":                    true,
-		"elasticsearch.gc_collection_time server_name young":               true,
-		"elasticsearch.gc_collection server_name old":                      true,
-		"elasticsearch.gc_collection_time server_name old":                 true,
-		"elasticsearch.memory_usage server_name heap":                      true,
-		"elasticsearch.memory_usage server_name non-heap":                  true,
-		"elasticsearch.network server_name transmit":                       true,
-		"elasticsearch.network server_name receive":                        true,
-		"elasticsearch.current_documents server_name live":                 true,
-		"elasticsearch.current_documents server_name deleted":              true,
-		"elasticsearch.http_connections server_name":                       true,
-		"elasticsearch.open_files server_name":                             true,
-		"elasticsearch.server_connections server_name":                     true,
-		"elasticsearch.operations server_name get":                         true,
-		"elasticsearch.operations server_name delete":                      true,
-		"elasticsearch.operations server_name index":                       true,
-		"elasticsearch.operations server_name query":                       true,
-		"elasticsearch.operations server_name fetch":                       true,
-		"elasticsearch.operation_time server_name get":                     true,
-		"elasticsearch.operation_time server_name delete":                  true,
-		"elasticsearch.operation_time server_name index":                   true,
-		"elasticsearch.operation_time server_name query":                   true,
-		"elasticsearch.operation_time server_name fetch":                   true,
-		"elasticsearch.peak_threads server_name":                           true,
-		"elasticsearch.storage_size server_name":                           true,
-		"elasticsearch.threads server_name":                                true,
-		"elasticsearch.data_nodes":                                         true,
-		"elasticsearch.nodes":                                              true,
-		"elasticsearch.shards unassigned":                                  true,
-		"elasticsearch.shards active":                                      true,
-		"elasticsearch.shards relocating":                                  true,
-		"elasticsearch.shards initializing":                                true,
-		"elasticsearch.thread_pool.active server_name thread_pool_name":    true,
-		"elasticsearch.thread_pool.completed server_name thread_pool_name": true,
-		"elasticsearch.thread_pool.queue server_name thread_pool_name":     true,
-		"elasticsearch.thread_pool.rejected server_name thread_pool_name":  true,
-		"elasticsearch.thread_pool.threads server_name thread_pool_name":   true,
-	}, exists)
+	expected := map[string]bool{
+		"elasticsearch.cache_memory_usage field":                      true,
+		"elasticsearch.cache_memory_usage query":                      true,
+		"elasticsearch.cache_memory_usage request":                    true,
+		"elasticsearch.evictions field":                               true,
+		"elasticsearch.evictions query":                               true,
+		"elasticsearch.evictions request":                             true,
+		"elasticsearch.gc_collection young":                           true,
+		"elasticsearch.gc_collection_time young":                      true,
+		"elasticsearch.gc_collection old":                             true,
+		"elasticsearch.gc_collection_time old":                        true,
+		"elasticsearch.memory_usage heap":                             true,
+		"elasticsearch.memory_usage non-heap":                         true,
+		"elasticsearch.network transmit":                              true,
+		"elasticsearch.network receive":                               true,
+		"elasticsearch.current_documents live":                        true,
+		"elasticsearch.current_documents deleted":                     true,
+		"elasticsearch.http_connections":                              true,
+		"elasticsearch.open_files":                                    true,
+		"elasticsearch.server_connections":                            true,
+		"elasticsearch.operations get":                                true,
+		"elasticsearch.operations delete":                             true,
+		"elasticsearch.operations index":                              true,
+		"elasticsearch.operations query":                              true,
+		"elasticsearch.operations fetch":                              true,
+		"elasticsearch.operation_time get":                            true,
+		"elasticsearch.operation_time delete":                         true,
+		"elasticsearch.operation_time index":                          true,
+		"elasticsearch.operation_time query":                          true,
+		"elasticsearch.operation_time fetch":                          true,
+		"elasticsearch.peak_threads":                                  true,
+		"elasticsearch.storage_size":                                  true,
+		"elasticsearch.threads":                                       true,
+		"elasticsearch.data_nodes":                                    true,
+		"elasticsearch.nodes":                                         true,
+		"elasticsearch.shards unassigned":                             true,
+		"elasticsearch.shards active":                                 true,
+		"elasticsearch.shards relocating":                             true,
+		"elasticsearch.shards initializing":                           true,
+		"elasticsearch.cluster_health green":                          true,
+		"elasticsearch.cluster_health yellow":                         true,
+		"elasticsearch.cluster_health red":                            true,
+		"elasticsearch.in_flight_fetches":                             true,
+		"elasticsearch.pending_tasks immediate":                       true,
+		"elasticsearch.pending_tasks urgent":                          true,
+		"elasticsearch.pending_tasks high":                            true,
+		"elasticsearch.pending_tasks normal":                          true,
+		"elasticsearch.pending_tasks low":                             true,
+		"elasticsearch.pending_tasks languid":                         true,
+		"elasticsearch.thread_pool.active thread_pool_name":           true,
+		"elasticsearch.thread_pool.completed thread_pool_name":        true,
+		"elasticsearch.thread_pool.queue thread_pool_name":            true,
+		"elasticsearch.thread_pool.rejected thread_pool_name":         true,
+		"elasticsearch.thread_pool.threads thread_pool_name":          true,
+		"elasticsearch.breaker.memory.estimated circuit_breaker_name": true,
+		"elasticsearch.breaker.memory.limit circuit_breaker_name":     true,
+		"elasticsearch.breaker.tripped circuit_breaker_name":          true,
+		"elasticsearch.memory_pool.used memory_pool_name":             true,
+		"elasticsearch.memory_pool.max memory_pool_name":              true,
+		"elasticsearch.disk.total":                                    true,
+		"elasticsearch.disk.available":                                true,
+		"elasticsearch.cpu_usage":                                     true,
+	}
+	if indexingPressure {
+		for _, key := range indexingPressureKeys {
+			expected[key] = true
+		}
+	}
+	require.Equal(t, expected, exists)
 }
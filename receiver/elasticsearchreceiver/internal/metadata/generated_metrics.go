@@ -41,41 +41,90 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	ElasticsearchCacheMemoryUsage    MetricIntf
-	ElasticsearchCurrentDocuments    MetricIntf
-	ElasticsearchDataNodes           MetricIntf
-	ElasticsearchEvictions           MetricIntf
-	ElasticsearchGcCollection        MetricIntf
-	ElasticsearchGcCollectionTime    MetricIntf
-	ElasticsearchHTTPConnections     MetricIntf
-	ElasticsearchMemoryUsage         MetricIntf
-	ElasticsearchNetwork             MetricIntf
-	ElasticsearchNodes               MetricIntf
-	ElasticsearchOpenFiles           MetricIntf
-	ElasticsearchOperationTime       MetricIntf
-	ElasticsearchOperations          MetricIntf
-	ElasticsearchPeakThreads         MetricIntf
-	ElasticsearchServerConnections   MetricIntf
-	ElasticsearchShards              MetricIntf
-	ElasticsearchStorageSize         MetricIntf
-	ElasticsearchThreadPoolActive    MetricIntf
-	ElasticsearchThreadPoolCompleted MetricIntf
-	ElasticsearchThreadPoolQueue     MetricIntf
-	ElasticsearchThreadPoolRejected  MetricIntf
-	ElasticsearchThreadPoolThreads   MetricIntf
-	ElasticsearchThreads             MetricIntf
+	ElasticsearchBreakerMemoryEstimated        MetricIntf
+	ElasticsearchBreakerMemoryLimit            MetricIntf
+	ElasticsearchBreakerTripped                MetricIntf
+	ElasticsearchCacheMemoryUsage              MetricIntf
+	ElasticsearchClusterHealth                 MetricIntf
+	ElasticsearchCPUUsage                      MetricIntf
+	ElasticsearchCurrentDocuments              MetricIntf
+	ElasticsearchDataNodes                     MetricIntf
+	ElasticsearchDiskAvailable                 MetricIntf
+	ElasticsearchDiskTotal                     MetricIntf
+	ElasticsearchEvictions                     MetricIntf
+	ElasticsearchGcCollection                  MetricIntf
+	ElasticsearchGcCollectionTime              MetricIntf
+	ElasticsearchHTTPConnections               MetricIntf
+	ElasticsearchIlmFailedIndices              MetricIntf
+	ElasticsearchIlmIndices                    MetricIntf
+	ElasticsearchInFlightFetches               MetricIntf
+	ElasticsearchIndexDocuments                MetricIntf
+	ElasticsearchIndexOperationTime            MetricIntf
+	ElasticsearchIndexOperations               MetricIntf
+	ElasticsearchIndexSegments                 MetricIntf
+	ElasticsearchIndexShards                   MetricIntf
+	ElasticsearchIndexStorageSize              MetricIntf
+	ElasticsearchIndexingPressureMemory        MetricIntf
+	ElasticsearchIndexingPressureMemoryLimit   MetricIntf
+	ElasticsearchIndexingPressureRejections    MetricIntf
+	ElasticsearchMemoryPoolMax                 MetricIntf
+	ElasticsearchMemoryPoolUsed                MetricIntf
+	ElasticsearchMemoryUsage                   MetricIntf
+	ElasticsearchNetwork                       MetricIntf
+	ElasticsearchNodes                         MetricIntf
+	ElasticsearchOpenFiles                     MetricIntf
+	ElasticsearchOperationTime                 MetricIntf
+	ElasticsearchOperations                    MetricIntf
+	ElasticsearchPeakThreads                   MetricIntf
+	ElasticsearchPendingTasks                  MetricIntf
+	ElasticsearchServerConnections             MetricIntf
+	ElasticsearchShards                        MetricIntf
+	ElasticsearchSnapshotPolicyLastFailure     MetricIntf
+	ElasticsearchSnapshotPolicyLastSuccess     MetricIntf
+	ElasticsearchSnapshotPolicySnapshots       MetricIntf
+	ElasticsearchSnapshotRepositories          MetricIntf
+	ElasticsearchSnapshotRepositoryLastSuccess MetricIntf
+	ElasticsearchSnapshotRepositorySnapshots   MetricIntf
+	ElasticsearchStorageSize                   MetricIntf
+	ElasticsearchThreadPoolActive              MetricIntf
+	ElasticsearchThreadPoolCompleted           MetricIntf
+	ElasticsearchThreadPoolQueue               MetricIntf
+	ElasticsearchThreadPoolRejected            MetricIntf
+	ElasticsearchThreadPoolThreads             MetricIntf
+	ElasticsearchThreads                       MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"elasticsearch.breaker.memory.estimated",
+		"elasticsearch.breaker.memory.limit",
+		"elasticsearch.breaker.tripped",
 		"elasticsearch.cache_memory_usage",
+		"elasticsearch.cluster_health",
+		"elasticsearch.cpu_usage",
 		"elasticsearch.current_documents",
 		"elasticsearch.data_nodes",
+		"elasticsearch.disk.available",
+		"elasticsearch.disk.total",
 		"elasticsearch.evictions",
 		"elasticsearch.gc_collection",
 		"elasticsearch.gc_collection_time",
 		"elasticsearch.http_connections",
+		"elasticsearch.ilm.failed_indices",
+		"elasticsearch.ilm.indices",
+		"elasticsearch.in_flight_fetches",
+		"elasticsearch.index.documents",
+		"elasticsearch.index.operation_time",
+		"elasticsearch.index.operations",
+		"elasticsearch.index.segments",
+		"elasticsearch.index.shards",
+		"elasticsearch.index.storage_size",
+		"elasticsearch.indexing_pressure.memory",
+		"elasticsearch.indexing_pressure.memory.limit",
+		"elasticsearch.indexing_pressure.rejections",
+		"elasticsearch.memory_pool.max",
+		"elasticsearch.memory_pool.used",
 		"elasticsearch.memory_usage",
 		"elasticsearch.network",
 		"elasticsearch.nodes",
@@ -83,8 +132,15 @@ func (m *metricStruct) Names() []string {
 		"elasticsearch.operation_time",
 		"elasticsearch.operations",
 		"elasticsearch.peak_threads",
+		"elasticsearch.pending_tasks",
 		"elasticsearch.server_connections",
 		"elasticsearch.shards",
+		"elasticsearch.snapshot.policy.last_failure",
+		"elasticsearch.snapshot.policy.last_success",
+		"elasticsearch.snapshot.policy.snapshots",
+		"elasticsearch.snapshot.repositories",
+		"elasticsearch.snapshot.repository.last_success",
+		"elasticsearch.snapshot.repository.snapshots",
 		"elasticsearch.storage_size",
 		"elasticsearch.thread_pool.active",
 		"elasticsearch.thread_pool.completed",
@@ -96,29 +152,57 @@ func (m *metricStruct) Names() []string {
 }
 
 var metricsByName = map[string]MetricIntf{
-	"elasticsearch.cache_memory_usage":    Metrics.ElasticsearchCacheMemoryUsage,
-	"elasticsearch.current_documents":     Metrics.ElasticsearchCurrentDocuments,
-	"elasticsearch.data_nodes":            Metrics.ElasticsearchDataNodes,
-	"elasticsearch.evictions":             Metrics.ElasticsearchEvictions,
-	"elasticsearch.gc_collection":         Metrics.ElasticsearchGcCollection,
-	"elasticsearch.gc_collection_time":    Metrics.ElasticsearchGcCollectionTime,
-	"elasticsearch.http_connections":      Metrics.ElasticsearchHTTPConnections,
-	"elasticsearch.memory_usage":          Metrics.ElasticsearchMemoryUsage,
-	"elasticsearch.network":               Metrics.ElasticsearchNetwork,
-	"elasticsearch.nodes":                 Metrics.ElasticsearchNodes,
-	"elasticsearch.open_files":            Metrics.ElasticsearchOpenFiles,
-	"elasticsearch.operation_time":        Metrics.ElasticsearchOperationTime,
-	"elasticsearch.operations":            Metrics.ElasticsearchOperations,
-	"elasticsearch.peak_threads":          Metrics.ElasticsearchPeakThreads,
-	"elasticsearch.server_connections":    Metrics.ElasticsearchServerConnections,
-	"elasticsearch.shards":                Metrics.ElasticsearchShards,
-	"elasticsearch.storage_size":          Metrics.ElasticsearchStorageSize,
-	"elasticsearch.thread_pool.active":    Metrics.ElasticsearchThreadPoolActive,
-	"elasticsearch.thread_pool.completed": Metrics.ElasticsearchThreadPoolCompleted,
-	"elasticsearch.thread_pool.queue":     Metrics.ElasticsearchThreadPoolQueue,
-	"elasticsearch.thread_pool.rejected":  Metrics.ElasticsearchThreadPoolRejected,
-	"elasticsearch.thread_pool.threads":   Metrics.ElasticsearchThreadPoolThreads,
-	"elasticsearch.threads":               Metrics.ElasticsearchThreads,
+	"elasticsearch.breaker.memory.estimated":         Metrics.ElasticsearchBreakerMemoryEstimated,
+	"elasticsearch.breaker.memory.limit":             Metrics.ElasticsearchBreakerMemoryLimit,
+	"elasticsearch.breaker.tripped":                  Metrics.ElasticsearchBreakerTripped,
+	"elasticsearch.cache_memory_usage":               Metrics.ElasticsearchCacheMemoryUsage,
+	"elasticsearch.cluster_health":                   Metrics.ElasticsearchClusterHealth,
+	"elasticsearch.cpu_usage":                        Metrics.ElasticsearchCPUUsage,
+	"elasticsearch.current_documents":                Metrics.ElasticsearchCurrentDocuments,
+	"elasticsearch.data_nodes":                       Metrics.ElasticsearchDataNodes,
+	"elasticsearch.disk.available":                   Metrics.ElasticsearchDiskAvailable,
+	"elasticsearch.disk.total":                       Metrics.ElasticsearchDiskTotal,
+	"elasticsearch.evictions":                        Metrics.ElasticsearchEvictions,
+	"elasticsearch.gc_collection":                    Metrics.ElasticsearchGcCollection,
+	"elasticsearch.gc_collection_time":               Metrics.ElasticsearchGcCollectionTime,
+	"elasticsearch.http_connections":                 Metrics.ElasticsearchHTTPConnections,
+	"elasticsearch.ilm.failed_indices":               Metrics.ElasticsearchIlmFailedIndices,
+	"elasticsearch.ilm.indices":                      Metrics.ElasticsearchIlmIndices,
+	"elasticsearch.in_flight_fetches":                Metrics.ElasticsearchInFlightFetches,
+	"elasticsearch.index.documents":                  Metrics.ElasticsearchIndexDocuments,
+	"elasticsearch.index.operation_time":             Metrics.ElasticsearchIndexOperationTime,
+	"elasticsearch.index.operations":                 Metrics.ElasticsearchIndexOperations,
+	"elasticsearch.index.segments":                   Metrics.ElasticsearchIndexSegments,
+	"elasticsearch.index.shards":                     Metrics.ElasticsearchIndexShards,
+	"elasticsearch.index.storage_size":               Metrics.ElasticsearchIndexStorageSize,
+	"elasticsearch.indexing_pressure.memory":         Metrics.ElasticsearchIndexingPressureMemory,
+	"elasticsearch.indexing_pressure.memory.limit":   Metrics.ElasticsearchIndexingPressureMemoryLimit,
+	"elasticsearch.indexing_pressure.rejections":     Metrics.ElasticsearchIndexingPressureRejections,
+	"elasticsearch.memory_pool.max":                  Metrics.ElasticsearchMemoryPoolMax,
+	"elasticsearch.memory_pool.used":                 Metrics.ElasticsearchMemoryPoolUsed,
+	"elasticsearch.memory_usage":                     Metrics.ElasticsearchMemoryUsage,
+	"elasticsearch.network":                          Metrics.ElasticsearchNetwork,
+	"elasticsearch.nodes":                            Metrics.ElasticsearchNodes,
+	"elasticsearch.open_files":                       Metrics.ElasticsearchOpenFiles,
+	"elasticsearch.operation_time":                   Metrics.ElasticsearchOperationTime,
+	"elasticsearch.operations":                       Metrics.ElasticsearchOperations,
+	"elasticsearch.peak_threads":                     Metrics.ElasticsearchPeakThreads,
+	"elasticsearch.pending_tasks":                    Metrics.ElasticsearchPendingTasks,
+	"elasticsearch.server_connections":               Metrics.ElasticsearchServerConnections,
+	"elasticsearch.shards":                           Metrics.ElasticsearchShards,
+	"elasticsearch.snapshot.policy.last_failure":     Metrics.ElasticsearchSnapshotPolicyLastFailure,
+	"elasticsearch.snapshot.policy.last_success":     Metrics.ElasticsearchSnapshotPolicyLastSuccess,
+	"elasticsearch.snapshot.policy.snapshots":        Metrics.ElasticsearchSnapshotPolicySnapshots,
+	"elasticsearch.snapshot.repositories":            Metrics.ElasticsearchSnapshotRepositories,
+	"elasticsearch.snapshot.repository.last_success": Metrics.ElasticsearchSnapshotRepositoryLastSuccess,
+	"elasticsearch.snapshot.repository.snapshots":    Metrics.ElasticsearchSnapshotRepositorySnapshots,
+	"elasticsearch.storage_size":                     Metrics.ElasticsearchStorageSize,
+	"elasticsearch.thread_pool.active":               Metrics.ElasticsearchThreadPoolActive,
+	"elasticsearch.thread_pool.completed":            Metrics.ElasticsearchThreadPoolCompleted,
+	"elasticsearch.thread_pool.queue":                Metrics.ElasticsearchThreadPoolQueue,
+	"elasticsearch.thread_pool.rejected":             Metrics.ElasticsearchThreadPoolRejected,
+	"elasticsearch.thread_pool.threads":              Metrics.ElasticsearchThreadPoolThreads,
+	"elasticsearch.threads":                          Metrics.ElasticsearchThreads,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -128,6 +212,35 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"elasticsearch.breaker.memory.estimated",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.breaker.memory.estimated")
+			metric.SetDescription("Estimated memory used by the operations tracked by the circuit breaker.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.breaker.memory.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.breaker.memory.limit")
+			metric.SetDescription("Memory limit of the circuit breaker.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.breaker.tripped",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.breaker.tripped")
+			metric.SetDescription("Number of times the circuit breaker has been triggered and prevented an out of memory error.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"elasticsearch.cache_memory_usage",
 		func(metric pdata.Metric) {
@@ -137,6 +250,24 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"elasticsearch.cluster_health",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.cluster_health")
+			metric.SetDescription("Health status of the cluster, 1 for the current status and 0 for the others.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.cpu_usage",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.cpu_usage")
+			metric.SetDescription("Recent CPU usage of the whole system.")
+			metric.SetUnit("%")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"elasticsearch.current_documents",
 		func(metric pdata.Metric) {
@@ -155,6 +286,24 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"elasticsearch.disk.available",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.disk.available")
+			metric.SetDescription("Size in bytes available to the JVM on the file stores of the node.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.disk.total",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.disk.total")
+			metric.SetDescription("Total size in bytes of the file stores of the node.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"elasticsearch.evictions",
 		func(metric pdata.Metric) {
@@ -197,6 +346,138 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"elasticsearch.ilm.failed_indices",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.ilm.failed_indices")
+			metric.SetDescription("Number of indices in each phase whose index lifecycle policy failed to execute a step.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.ilm.indices",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.ilm.indices")
+			metric.SetDescription("Number of indices managed by index lifecycle policies in each phase.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.in_flight_fetches",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.in_flight_fetches")
+			metric.SetDescription("Number of unfinished shard fetches.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.index.documents",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.index.documents")
+			metric.SetDescription("Number of documents in the primary shards of the index.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.index.operation_time",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.index.operation_time")
+			metric.SetDescription("Time in ms spent on operations on all shards of the index.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.index.operations",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.index.operations")
+			metric.SetDescription("Number of operations completed on all shards of the index.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.index.segments",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.index.segments")
+			metric.SetDescription("Number of segments of the index on all of its shards.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.index.shards",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.index.shards")
+			metric.SetDescription("Number of shards of the index.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.index.storage_size",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.index.storage_size")
+			metric.SetDescription("Size in bytes of the index on all of its shards.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.indexing_pressure.memory",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.indexing_pressure.memory")
+			metric.SetDescription("Memory consumed by outstanding indexing requests in each indexing stage.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.indexing_pressure.memory.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.indexing_pressure.memory.limit")
+			metric.SetDescription("Memory limit of outstanding indexing requests, beyond which new requests are rejected.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.indexing_pressure.rejections",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.indexing_pressure.rejections")
+			metric.SetDescription("Number of indexing requests rejected in each indexing stage.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.memory_pool.max",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.memory_pool.max")
+			metric.SetDescription("Maximum memory of the JVM memory pool.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.memory_pool.used",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.memory_pool.used")
+			metric.SetDescription("Memory used by the JVM memory pool.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"elasticsearch.memory_usage",
 		func(metric pdata.Metric) {
@@ -266,6 +547,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"elasticsearch.pending_tasks",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.pending_tasks")
+			metric.SetDescription("Number of cluster-level changes that have not yet been executed.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"elasticsearch.server_connections",
 		func(metric pdata.Metric) {
@@ -284,6 +574,62 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"elasticsearch.snapshot.policy.last_failure",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.snapshot.policy.last_failure")
+			metric.SetDescription("Time of the last failed snapshot of the policy, in milliseconds since the epoch.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.snapshot.policy.last_success",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.snapshot.policy.last_success")
+			metric.SetDescription("Time of the last successful snapshot of the policy, in milliseconds since the epoch.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.snapshot.policy.snapshots",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.snapshot.policy.snapshots")
+			metric.SetDescription("Number of snapshots taken and deleted by the snapshot lifecycle policy, and of failures doing so.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.snapshot.repositories",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.snapshot.repositories")
+			metric.SetDescription("Number of registered snapshot repositories.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.snapshot.repository.last_success",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.snapshot.repository.last_success")
+			metric.SetDescription("Time the latest successful snapshot in the snapshot repository finished, in milliseconds since the epoch.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"elasticsearch.snapshot.repository.snapshots",
+		func(metric pdata.Metric) {
+			metric.SetName("elasticsearch.snapshot.repository.snapshots")
+			metric.SetDescription("Number of snapshots in the snapshot repository in each state.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"elasticsearch.storage_size",
 		func(metric pdata.Metric) {
@@ -361,31 +707,67 @@ var M = Metrics
 var Attributes = struct {
 	// CacheName (Type of cache)
 	CacheName string
+	// CircuitBreakerName (Name of the circuit breaker.)
+	CircuitBreakerName string
 	// Direction (Data direction)
 	Direction string
 	// DocumentType (Type of document count)
 	DocumentType string
 	// GcType (Type of garbage collection)
 	GcType string
+	// HealthStatus (Health status of the cluster)
+	HealthStatus string
+	// IlmPhase (Index lifecycle phase)
+	IlmPhase string
+	// IndexName (The name of the index.)
+	IndexName string
+	// IndexingPressureStage (Stage of the indexing pressure)
+	IndexingPressureStage string
+	// MemoryPoolName (Name of the JVM memory pool.)
+	MemoryPoolName string
 	// MemoryType (Type of memory)
 	MemoryType string
 	// Operation (Type of operation)
 	Operation string
-	// ServerName (The name of the server or node the metric is based on.)
-	ServerName string
+	// PolicyName (Name of the snapshot lifecycle policy.)
+	PolicyName string
+	// RepositoryName (Name of the snapshot repository.)
+	RepositoryName string
+	// RepositoryType (Type of the snapshot repository, such as fs or s3.)
+	RepositoryType string
+	// ShardRole (Role of the shard)
+	ShardRole string
 	// ShardType (State of the shard)
 	ShardType string
+	// SnapshotOutcome (Outcome of the snapshot lifecycle operation)
+	SnapshotOutcome string
+	// SnapshotState (State of the snapshot)
+	SnapshotState string
+	// TaskPriority (Priority of the pending task)
+	TaskPriority string
 	// ThreadPoolName (Thread pool name)
 	ThreadPoolName string
 }{
 	"cache_name",
+	"circuit_breaker_name",
 	"direction",
 	"document_type",
 	"gc_type",
+	"health_status",
+	"ilm_phase",
+	"index_name",
+	"indexing_pressure_stage",
+	"memory_pool_name",
 	"memory_type",
 	"operation",
-	"server_name",
+	"policy_name",
+	"repository_name",
+	"repository_type",
+	"shard_role",
 	"shard_type",
+	"snapshot_outcome",
+	"snapshot_state",
+	"task_priority",
 	"thread_pool_name",
 }
 
@@ -430,6 +812,45 @@ var AttributeGcType = struct {
 	"old",
 }
 
+// AttributeHealthStatus are the possible values that the attribute "health_status" can have.
+var AttributeHealthStatus = struct {
+	Green  string
+	Yellow string
+	Red    string
+}{
+	"green",
+	"yellow",
+	"red",
+}
+
+// AttributeIlmPhase are the possible values that the attribute "ilm_phase" can have.
+var AttributeIlmPhase = struct {
+	New    string
+	Hot    string
+	Warm   string
+	Cold   string
+	Frozen string
+	Delete string
+}{
+	"new",
+	"hot",
+	"warm",
+	"cold",
+	"frozen",
+	"delete",
+}
+
+// AttributeIndexingPressureStage are the possible values that the attribute "indexing_pressure_stage" can have.
+var AttributeIndexingPressureStage = struct {
+	Coordinating string
+	Primary      string
+	Replica      string
+}{
+	"coordinating",
+	"primary",
+	"replica",
+}
+
 // AttributeMemoryType are the possible values that the attribute "memory_type" can have.
 var AttributeMemoryType = struct {
 	Heap    string
@@ -454,6 +875,15 @@ var AttributeOperation = struct {
 	"fetch",
 }
 
+// AttributeShardRole are the possible values that the attribute "shard_role" can have.
+var AttributeShardRole = struct {
+	Primary string
+	Replica string
+}{
+	"primary",
+	"replica",
+}
+
 // AttributeShardType are the possible values that the attribute "shard_type" can have.
 var AttributeShardType = struct {
 	Active       string
@@ -466,3 +896,48 @@ var AttributeShardType = struct {
 	"initializing",
 	"unassigned",
 }
+
+// AttributeSnapshotOutcome are the possible values that the attribute "snapshot_outcome" can have.
+var AttributeSnapshotOutcome = struct {
+	Taken          string
+	Failed         string
+	Deleted        string
+	DeletionFailed string
+}{
+	"taken",
+	"failed",
+	"deleted",
+	"deletion_failed",
+}
+
+// AttributeSnapshotState are the possible values that the attribute "snapshot_state" can have.
+var AttributeSnapshotState = struct {
+	InProgress   string
+	Success      string
+	Partial      string
+	Failed       string
+	Incompatible string
+}{
+	"in_progress",
+	"success",
+	"partial",
+	"failed",
+	"incompatible",
+}
+
+// AttributeTaskPriority are the possible values that the attribute "task_priority" can have.
+var AttributeTaskPriority = struct {
+	Immediate string
+	Urgent    string
+	High      string
+	Normal    string
+	Low       string
+	Languid   string
+}{
+	"immediate",
+	"urgent",
+	"high",
+	"normal",
+	"low",
+	"languid",
+}
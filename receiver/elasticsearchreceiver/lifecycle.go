@@ -0,0 +1,255 @@
+package elasticsearchreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/observiq/opentelemetry-components/receiver/elasticsearchreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+const (
+	// snapshotRepositoriesPath lists the registered snapshot repositories.
+	snapshotRepositoriesPath = "/_snapshot/_all"
+	// catSnapshotsPath lists the state and end time of the snapshots in a snapshot repository.
+	catSnapshotsPath = "/_cat/snapshots/%s?format=json&h=id,status,end_epoch"
+	// slmStatsPath returns the snapshot counts of every snapshot lifecycle policy.
+	slmStatsPath = "/_slm/stats"
+	// slmPolicyPath returns the last successful and failed snapshot of every snapshot lifecycle policy.
+	slmPolicyPath = "/_slm/policy"
+	// ilmExplainPath returns the index lifecycle state of every index.
+	ilmExplainPath = "/_all/_ilm/explain"
+)
+
+const (
+	// snapshotMetricsCount and ilmMetricsCount are the number of metrics missing if the snapshot or
+	// index lifecycle metrics cannot be read.
+	snapshotMetricsCount = 6
+	ilmMetricsCount      = 2
+)
+
+// ilmErrorStep is the step of an index whose lifecycle policy failed to execute a step.
+const ilmErrorStep = "ERROR"
+
+// snapshotStates maps the status column of cat snapshots to the snapshot_state attribute.
+var snapshotStates = map[string]string{
+	"IN_PROGRESS":  metadata.AttributeSnapshotState.InProgress,
+	"SUCCESS":      metadata.AttributeSnapshotState.Success,
+	"PARTIAL":      metadata.AttributeSnapshotState.Partial,
+	"FAILED":       metadata.AttributeSnapshotState.Failed,
+	"INCOMPATIBLE": metadata.AttributeSnapshotState.Incompatible,
+}
+
+// slmPolicySnapshots maps the per-policy fields of the SLM stats to the snapshot_outcome attribute.
+var slmPolicySnapshots = map[string]string{
+	"snapshots_taken":            metadata.AttributeSnapshotOutcome.Taken,
+	"snapshots_failed":           metadata.AttributeSnapshotOutcome.Failed,
+	"snapshots_deleted":          metadata.AttributeSnapshotOutcome.Deleted,
+	"snapshot_deletion_failures": metadata.AttributeSnapshotOutcome.DeletionFailed,
+}
+
+// scrapeSnapshotMetrics reports the snapshot repositories with the state of the snapshots in each, and the
+// snapshots of each snapshot lifecycle policy. OpenSearch manages snapshots with a plugin instead of SLM,
+// so only its repositories are reported.
+func (r *elasticsearchScraper) scrapeSnapshotMetrics(ctx context.Context, ms pdata.MetricSlice, distribution string) error {
+	repositoriesMetric := initMetric(ms, metadata.M.ElasticsearchSnapshotRepositories).Gauge().DataPoints()
+	repositorySnapshotsMetric := initMetric(ms, metadata.M.ElasticsearchSnapshotRepositorySnapshots).Gauge().DataPoints()
+	repositoryLastSuccessMetric := initMetric(ms, metadata.M.ElasticsearchSnapshotRepositoryLastSuccess).Gauge().DataPoints()
+
+	repositories, err := r.makeRequest(ctx, snapshotRepositoriesPath)
+	if err != nil {
+		return err
+	}
+	repositoryCounts := map[string]int64{}
+	repositoryNames := make([]string, 0, len(repositories))
+	for repositoryName, repositoryInter := range repositories {
+		repositoryNames = append(repositoryNames, repositoryName)
+		repository, ok := repositoryInter.(map[string]interface{})
+		if !ok {
+			r.logger.Info("could not reflect snapshot repository as a map")
+			continue
+		}
+		repositoryType, err := getStringFromBody([]string{"type"}, repository)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+		repositoryCounts[repositoryType]++
+	}
+	for repositoryType, count := range repositoryCounts {
+		attributes := pdata.NewAttributeMap()
+		attributes.Upsert(metadata.A.RepositoryType, pdata.NewAttributeValueString(repositoryType))
+		addToIntMetric(repositoriesMetric, attributes, count, r.now)
+	}
+
+	sort.Strings(repositoryNames)
+	for _, repositoryName := range repositoryNames {
+		if err := r.scrapeRepositorySnapshots(ctx, repositoryName, repositorySnapshotsMetric, repositoryLastSuccessMetric); err != nil {
+			return err
+		}
+	}
+
+	if distribution == distributionOpenSearch {
+		return nil
+	}
+
+	policySnapshotsMetric := initMetric(ms, metadata.M.ElasticsearchSnapshotPolicySnapshots).Sum().DataPoints()
+	lastSuccessMetric := initMetric(ms, metadata.M.ElasticsearchSnapshotPolicyLastSuccess).Gauge().DataPoints()
+	lastFailureMetric := initMetric(ms, metadata.M.ElasticsearchSnapshotPolicyLastFailure).Gauge().DataPoints()
+
+	slmStats, err := r.makeRequest(ctx, slmStatsPath)
+	if err != nil {
+		return err
+	}
+	policyStats, _ := slmStats["policy_stats"].([]interface{})
+	for _, policyInter := range policyStats {
+		policy, ok := policyInter.(map[string]interface{})
+		if !ok {
+			r.logger.Info("could not reflect snapshot lifecycle policy stats as a map")
+			continue
+		}
+		policyName, err := getStringFromBody([]string{"policy"}, policy)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+
+		attributes := pdata.NewAttributeMap()
+		attributes.Upsert(metadata.A.PolicyName, pdata.NewAttributeValueString(policyName))
+		for field, outcome := range slmPolicySnapshots {
+			attributes.Upsert(metadata.A.SnapshotOutcome, pdata.NewAttributeValueString(outcome))
+			r.processIntMetric([]string{field}, policy, policySnapshotsMetric, attributes)
+		}
+	}
+
+	policies, err := r.makeRequest(ctx, slmPolicyPath)
+	if err != nil {
+		return err
+	}
+	for policyName, policyInter := range policies {
+		policy, ok := policyInter.(map[string]interface{})
+		if !ok {
+			r.logger.Info("could not reflect snapshot lifecycle policy as a map")
+			continue
+		}
+
+		// policies that have not taken a snapshot yet have no last success or failure
+		attributes := pdata.NewAttributeMap()
+		attributes.Upsert(metadata.A.PolicyName, pdata.NewAttributeValueString(policyName))
+		if lastSuccess, err := getIntFromBody([]string{"last_success", "time"}, policy); err == nil {
+			addToIntMetric(lastSuccessMetric, attributes, lastSuccess, r.now)
+		}
+		if lastFailure, err := getIntFromBody([]string{"last_failure", "time"}, policy); err == nil {
+			addToIntMetric(lastFailureMetric, attributes, lastFailure, r.now)
+		}
+	}
+
+	return nil
+}
+
+// scrapeRepositorySnapshots reports the number of snapshots in a repository in each state, and when the latest
+// successful one finished. Every state is reported, so that the count drops back to 0 once a snapshot is no
+// longer in progress.
+func (r *elasticsearchScraper) scrapeRepositorySnapshots(ctx context.Context, repositoryName string, snapshotsMetric, lastSuccessMetric pdata.NumberDataPointSlice) error {
+	snapshots, err := r.makeArrayRequest(ctx, fmt.Sprintf(catSnapshotsPath, url.PathEscape(repositoryName)))
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int64{}
+	var lastSuccess int64
+	for _, snapshot := range snapshots {
+		status, err := getStringFromBody([]string{"status"}, snapshot)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+		state, ok := snapshotStates[status]
+		if !ok {
+			r.logger.Info("unknown snapshot status", zap.String("status", status))
+			continue
+		}
+		counts[state]++
+
+		if state != metadata.AttributeSnapshotState.Success {
+			continue
+		}
+		if endEpoch, err := getIntFromBody([]string{"end_epoch"}, snapshot); err == nil && endEpoch*1000 > lastSuccess {
+			lastSuccess = endEpoch * 1000
+		}
+	}
+
+	attributes := pdata.NewAttributeMap()
+	attributes.Upsert(metadata.A.RepositoryName, pdata.NewAttributeValueString(repositoryName))
+	// repositories without a successful snapshot have no last success
+	if lastSuccess > 0 {
+		addToIntMetric(lastSuccessMetric, attributes, lastSuccess, r.now)
+	}
+	for _, state := range snapshotStates {
+		attributes.Upsert(metadata.A.SnapshotState, pdata.NewAttributeValueString(state))
+		addToIntMetric(snapshotsMetric, attributes, counts[state], r.now)
+	}
+	return nil
+}
+
+// scrapeILMMetrics reports the number of indices managed by index lifecycle policies in each phase, and
+// how many of them failed to execute a step. OpenSearch manages indices with a plugin instead of ILM,
+// so nothing is reported for it.
+func (r *elasticsearchScraper) scrapeILMMetrics(ctx context.Context, ms pdata.MetricSlice, distribution string) error {
+	if distribution == distributionOpenSearch {
+		return nil
+	}
+
+	indicesMetric := initMetric(ms, metadata.M.ElasticsearchIlmIndices).Gauge().DataPoints()
+	failedIndicesMetric := initMetric(ms, metadata.M.ElasticsearchIlmFailedIndices).Gauge().DataPoints()
+
+	explain, err := r.makeRequest(ctx, ilmExplainPath)
+	if err != nil {
+		return err
+	}
+	indices, err := getMapFromBody([]string{"indices"}, explain)
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int64{}
+	failedCounts := map[string]int64{}
+	for _, indexInter := range indices {
+		index, ok := indexInter.(map[string]interface{})
+		if !ok {
+			r.logger.Info("could not reflect index lifecycle state as a map")
+			continue
+		}
+		if managed, _ := index["managed"].(bool); !managed {
+			continue
+		}
+		phase, err := getStringFromBody([]string{"phase"}, index)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+		counts[phase]++
+		if step, _ := index["step"].(string); step == ilmErrorStep {
+			failedCounts[phase]++
+		}
+	}
+
+	attributes := pdata.NewAttributeMap()
+	for _, phase := range []string{
+		metadata.AttributeIlmPhase.New,
+		metadata.AttributeIlmPhase.Hot,
+		metadata.AttributeIlmPhase.Warm,
+		metadata.AttributeIlmPhase.Cold,
+		metadata.AttributeIlmPhase.Frozen,
+		metadata.AttributeIlmPhase.Delete,
+	} {
+		attributes.Upsert(metadata.A.IlmPhase, pdata.NewAttributeValueString(phase))
+		addToIntMetric(indicesMetric, attributes, counts[phase], r.now)
+		addToIntMetric(failedIndicesMetric, attributes, failedCounts[phase], r.now)
+	}
+
+	return nil
+}
@@ -7,20 +7,43 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/observiq/opentelemetry-components/receiver/elasticsearchreceiver/internal/metadata"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 )
 
+const (
+	// distributionResourceAttribute is either "elasticsearch" or "opensearch".
+	distributionResourceAttribute = "elasticsearch.distribution"
+	clusterNameResourceAttribute  = "elasticsearch.cluster.name"
+	nodeIDResourceAttribute       = "elasticsearch.node.id"
+	nodeNameResourceAttribute     = "elasticsearch.node.name"
+	nodeHostResourceAttribute     = "elasticsearch.node.host"
+	nodeVersionResourceAttribute  = "elasticsearch.node.version"
+	// nodeRolesResourceAttribute lists the roles of the node separated by commas, such as "data_hot,ingest,master".
+	nodeRolesResourceAttribute = "elasticsearch.node.roles"
+)
+
+// Values of the elasticsearch.distribution resource attribute.
+const (
+	distributionElasticsearch = "elasticsearch"
+	distributionOpenSearch    = "opensearch"
+)
+
 type elasticsearchScraper struct {
 	httpClient *http.Client
 	logger     *zap.Logger
 	cfg        *Config
 	now        pdata.Timestamp
+	// detectedDistribution is the distribution serving the endpoint, once it has been read.
+	detectedDistribution string
 }
 
 func newElasticSearchScraper(
@@ -43,35 +66,39 @@ func (r *elasticsearchScraper) start(_ context.Context, host component.Host) err
 	return nil
 }
 
+// processIntMetric adds the value at keys to the metric. Fields differ between versions and distributions,
+// so a missing value is skipped.
 func (r *elasticsearchScraper) processIntMetric(keys []string, body map[string]interface{}, metric pdata.NumberDataPointSlice, attributes pdata.AttributeMap) {
 	intVal, err := getIntFromBody(keys, body)
 	if err != nil {
-		r.logger.Info(err.Error())
+		r.logger.Debug(err.Error())
 	} else {
 		addToIntMetric(metric, attributes, intVal, r.now)
 	}
 }
 
-func (r *elasticsearchScraper) makeRequest(path string) (map[string]interface{}, error) {
-	req, err := http.NewRequest("GET", r.cfg.Endpoint+path, nil)
+func (r *elasticsearchScraper) makeRequest(ctx context.Context, path string) (map[string]interface{}, error) {
+	body, err := r.readResponse(ctx, path)
 	if err != nil {
 		return nil, err
 	}
-	if r.cfg.Username != "" && r.cfg.Password != "" {
-		req.Header.Add("Authorization", "Basic "+basicAuth(r.cfg.Username, r.cfg.Password))
-	}
 
-	resp, err := r.httpClient.Do(req)
+	var bodyParsed map[string]interface{}
+	err = json.Unmarshal(body, &bodyParsed)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	return bodyParsed, nil
+}
+
+// makeArrayRequest is makeRequest for the APIs that respond with a JSON array, such as the cat APIs.
+func (r *elasticsearchScraper) makeArrayRequest(ctx context.Context, path string) ([]map[string]interface{}, error) {
+	body, err := r.readResponse(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
-	var bodyParsed map[string]interface{}
+	var bodyParsed []map[string]interface{}
 	err = json.Unmarshal(body, &bodyParsed)
 	if err != nil {
 		return nil, err
@@ -79,21 +106,108 @@ func (r *elasticsearchScraper) makeRequest(path string) (map[string]interface{},
 	return bodyParsed, nil
 }
 
+func (r *elasticsearchScraper) readResponse(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.cfg.Endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization := r.authorization(); authorization != "" {
+		req.Header.Add("Authorization", authorization)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newHTTPError(path, resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// httpError is returned for requests that Elasticsearch responded to with a non-2xx status code.
+type httpError struct {
+	path       string
+	statusCode int
+	// errorType and reason are read from the error in the response body, if there is one.
+	errorType string
+	reason    string
+}
+
+func newHTTPError(path string, statusCode int, body []byte) *httpError {
+	httpErr := &httpError{path: path, statusCode: statusCode}
+
+	var errorBody struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err != nil || errorBody.Error == nil {
+		return httpErr
+	}
+
+	// most errors are objects with a type and reason, but some are reported as a plain string
+	var cause struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(errorBody.Error, &cause); err == nil {
+		httpErr.errorType = cause.Type
+		httpErr.reason = cause.Reason
+	} else {
+		_ = json.Unmarshal(errorBody.Error, &httpErr.reason)
+	}
+	return httpErr
+}
+
+func (e *httpError) Error() string {
+	msg := fmt.Sprintf("request to %s failed with status %d", e.path, e.statusCode)
+	if e.errorType != "" {
+		msg += fmt.Sprintf(": %s", e.errorType)
+	}
+	if e.reason != "" {
+		msg += fmt.Sprintf(": %s", e.reason)
+	}
+	return msg
+}
+
+// authorization returns the Authorization header for the configured credentials. Credentials from an
+// auth extension are added by the http client instead.
+func (r *elasticsearchScraper) authorization() string {
+	switch {
+	case r.cfg.APIKey != "":
+		apiKey := r.cfg.APIKey
+		if strings.Contains(apiKey, ":") {
+			apiKey = base64.StdEncoding.EncodeToString([]byte(apiKey))
+		}
+		return "ApiKey " + apiKey
+	case r.cfg.BearerToken != "":
+		return "Bearer " + r.cfg.BearerToken
+	case r.cfg.Username != "" && r.cfg.Password != "":
+		return "Basic " + basicAuth(r.cfg.Username, r.cfg.Password)
+	}
+	return ""
+}
+
 func basicAuth(username, password string) string {
 	auth := username + ":" + password
 	return base64.StdEncoding.EncodeToString([]byte(auth))
 }
 
-func (r *elasticsearchScraper) scrape(context.Context) (pdata.Metrics, error) {
-	nodeStats, err := r.makeRequest("/_nodes/stats")
+func (r *elasticsearchScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	distribution := r.distribution(ctx)
+
+	nodeStats, err := r.makeRequest(ctx, r.cfg.nodeStatsPath())
 	if err != nil {
 		return pdata.Metrics{}, err
 	}
 
 	r.now = pdata.NewTimestampFromTime(time.Now())
 	rms := pdata.NewMetrics()
-	ilm := rms.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
-	ilm.InstrumentationLibrary().SetName("otelcol/elasticsearch")
 	nodesInter, ok := nodeStats["nodes"]
 	if !ok {
 		return pdata.Metrics{}, fmt.Errorf("no nodes data available")
@@ -102,171 +216,529 @@ func (r *elasticsearchScraper) scrape(context.Context) (pdata.Metrics, error) {
 	if !ok {
 		return pdata.Metrics{}, fmt.Errorf("could not reflect set of nodes as a map")
 	}
+	var errs scrapererror.ScrapeErrors
+	r.addNodeFailures(nodeStats, &errs)
 
-	cacheMemoryUsageMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchCacheMemoryUsage).Gauge().DataPoints()
-	evictionsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchEvictions).Sum().DataPoints()
-	GCCollectionsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchGcCollection).Sum().DataPoints()
-	GCCollectionTimeMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchGcCollectionTime).Sum().DataPoints()
-	MemoryUsageMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchMemoryUsage).Gauge().DataPoints()
-	NetworkMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchNetwork).Sum().DataPoints()
-	CurrentDocsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchCurrentDocuments).Gauge().DataPoints()
-	DataNodesMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchDataNodes).Gauge().DataPoints()
-	HTTPConnsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchHTTPConnections).Gauge().DataPoints()
-	NodesMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchNodes).Gauge().DataPoints()
-	OpenFilesMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchOpenFiles).Gauge().DataPoints()
-	ServerConnsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchServerConnections).Gauge().DataPoints()
-	ShardsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchShards).Gauge().DataPoints()
-	OperationsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchOperations).Sum().DataPoints()
-	OperationTimeMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchOperationTime).Sum().DataPoints()
-	peakThreadsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchPeakThreads).Gauge().DataPoints()
-	storageSizeMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchStorageSize).Gauge().DataPoints()
-	threadsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchThreads).Gauge().DataPoints()
-	threadPoolThreadsMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchThreadPoolThreads).Gauge().DataPoints()
-	threadPoolQueueMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchThreadPoolQueue).Gauge().DataPoints()
-	threadPoolActiveMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchThreadPoolActive).Gauge().DataPoints()
-	threadPoolRejectedMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchThreadPoolRejected).Sum().DataPoints()
-	threadPoolCompletedMetric := initMetric(ilm.Metrics(), metadata.M.ElasticsearchThreadPoolCompleted).Sum().DataPoints()
-
-	for _, nodeDataInter := range nodes {
-		nodeData, ok := nodeDataInter.(map[string]interface{})
+	clusterName, err := getStringFromBody([]string{"cluster_name"}, nodeStats)
+	if err != nil {
+		r.logger.Info(err.Error())
+	}
+	versions := r.nodeVersions(ctx)
+
+	// nodes are reported in order of their id, so that resources keep their order between scrapes
+	nodeIDs := make([]string, 0, len(nodes))
+	for nodeID := range nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	for _, nodeID := range nodeIDs {
+		nodeData, ok := nodes[nodeID].(map[string]interface{})
 		if !ok {
 			r.logger.Error("could not reflect node data as a map")
 			continue
 		}
 
-		nodeName, err := getStringFromBody([]string{"name"}, nodeData)
-		if err != nil {
-			r.logger.Error(err.Error())
+		rm := rms.ResourceMetrics().AppendEmpty()
+		setClusterResourceAttributes(rm.Resource(), distribution, clusterName)
+		setNodeResourceAttributes(rm.Resource(), nodeID, versions[nodeID], nodeData)
+		r.scrapeNodeMetrics(nodeData, newMetricSlice(rm))
+	}
+
+	if r.cfg.SkipClusterMetrics {
+		return rms, errs.Combine()
+	}
+
+	rm := rms.ResourceMetrics().AppendEmpty()
+	setClusterResourceAttributes(rm.Resource(), distribution, clusterName)
+	ms := newMetricSlice(rm)
+
+	// failing to read the cluster or index metrics leaves the node metrics in place
+	if err := r.scrapeClusterMetrics(ctx, ms); err != nil {
+		errs.AddPartial(clusterMetricsCount, err)
+	}
+
+	if r.cfg.Indices.Enabled {
+		if err := r.scrapeIndexMetrics(ctx, ms); err != nil {
+			errs.AddPartial(indexMetricsCount, err)
+		}
+	}
+
+	// snapshot and index lifecycle metrics need privileges of their own, so failing to read them
+	// leaves the other metrics in place
+	if r.cfg.Snapshots.Enabled {
+		if err := r.scrapeSnapshotMetrics(ctx, ms, distribution); err != nil {
+			errs.AddPartial(snapshotMetricsCount, err)
+		}
+	}
+
+	if r.cfg.ILM.Enabled {
+		if err := r.scrapeILMMetrics(ctx, ms, distribution); err != nil {
+			errs.AddPartial(ilmMetricsCount, err)
+		}
+	}
+
+	return rms, errs.Combine()
+}
+
+func newMetricSlice(rm pdata.ResourceMetrics) pdata.MetricSlice {
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/elasticsearch")
+	return ilm.Metrics()
+}
+
+// nodeVersions returns the Elasticsearch version of each node by node id, which node stats do not include.
+// Nodes are still reported without a version if the node info cannot be read.
+func (r *elasticsearchScraper) nodeVersions(ctx context.Context) map[string]string {
+	nodeInfo, err := r.makeRequest(ctx, r.cfg.nodeInfoPath())
+	if err != nil {
+		r.logger.Warn("failed to read node versions", zap.Error(err))
+		return nil
+	}
+	nodes, err := getMapFromBody([]string{"nodes"}, nodeInfo)
+	if err != nil {
+		r.logger.Info(err.Error())
+		return nil
+	}
+
+	versions := make(map[string]string, len(nodes))
+	for nodeID, nodeInter := range nodes {
+		node, ok := nodeInter.(map[string]interface{})
+		if !ok {
 			continue
 		}
+		if version, err := getStringFromBody([]string{"version"}, node); err == nil {
+			versions[nodeID] = version
+		}
+	}
+	return versions
+}
 
-		attributes := pdata.NewAttributeMap()
-		attributes.Upsert(metadata.A.ServerName, pdata.NewAttributeValueString(nodeName))
-
-		attributes.Upsert(metadata.A.CacheName, pdata.NewAttributeValueString("query"))
-		r.processIntMetric([]string{"indices", "query_cache", "memory_size_in_bytes"}, nodeData, cacheMemoryUsageMetric, attributes)
-		r.processIntMetric([]string{"indices", "query_cache", "evictions"}, nodeData, evictionsMetric, attributes)
-		attributes.Upsert(metadata.A.CacheName, pdata.NewAttributeValueString("request"))
-		r.processIntMetric([]string{"indices", "request_cache", "memory_size_in_bytes"}, nodeData, cacheMemoryUsageMetric, attributes)
-		r.processIntMetric([]string{"indices", "request_cache", "evictions"}, nodeData, evictionsMetric, attributes)
-		attributes.Upsert(metadata.A.CacheName, pdata.NewAttributeValueString("field"))
-		r.processIntMetric([]string{"indices", "fielddata", "memory_size_in_bytes"}, nodeData, cacheMemoryUsageMetric, attributes)
-		r.processIntMetric([]string{"indices", "fielddata", "evictions"}, nodeData, evictionsMetric, attributes)
-		attributes.Delete(metadata.A.CacheName)
-
-		attributes.Upsert(metadata.A.GcType, pdata.NewAttributeValueString("young"))
-		r.processIntMetric([]string{"jvm", "gc", "collectors", "young", "collection_count"}, nodeData, GCCollectionsMetric, attributes)
-		r.processIntMetric([]string{"jvm", "gc", "collectors", "young", "collection_time_in_millis"}, nodeData, GCCollectionTimeMetric, attributes)
-		attributes.Upsert(metadata.A.GcType, pdata.NewAttributeValueString("old"))
-		r.processIntMetric([]string{"jvm", "gc", "collectors", "old", "collection_count"}, nodeData, GCCollectionsMetric, attributes)
-		r.processIntMetric([]string{"jvm", "gc", "collectors", "old", "collection_time_in_millis"}, nodeData, GCCollectionTimeMetric, attributes)
-		attributes.Delete(metadata.A.GcType)
-
-		attributes.Upsert(metadata.A.MemoryType, pdata.NewAttributeValueString("heap"))
-		r.processIntMetric([]string{"jvm", "mem", "heap_used_in_bytes"}, nodeData, MemoryUsageMetric, attributes)
-		attributes.Upsert(metadata.A.MemoryType, pdata.NewAttributeValueString("non-heap"))
-		r.processIntMetric([]string{"jvm", "mem", "non_heap_used_in_bytes"}, nodeData, MemoryUsageMetric, attributes)
-		attributes.Delete(metadata.A.MemoryType)
-
-		attributes.Upsert(metadata.A.Direction, pdata.NewAttributeValueString("receive"))
-		r.processIntMetric([]string{"transport", "rx_size_in_bytes"}, nodeData, NetworkMetric, attributes)
-		attributes.Upsert(metadata.A.Direction, pdata.NewAttributeValueString("transmit"))
-		r.processIntMetric([]string{"transport", "tx_size_in_bytes"}, nodeData, NetworkMetric, attributes)
-		attributes.Delete(metadata.A.Direction)
-
-		attributes.Upsert(metadata.A.DocumentType, pdata.NewAttributeValueString("live"))
-		r.processIntMetric([]string{"indices", "docs", "count"}, nodeData, CurrentDocsMetric, attributes)
-		attributes.Upsert(metadata.A.DocumentType, pdata.NewAttributeValueString("deleted"))
-		r.processIntMetric([]string{"indices", "docs", "deleted"}, nodeData, CurrentDocsMetric, attributes)
-		attributes.Delete(metadata.A.DocumentType)
-
-		r.processIntMetric([]string{"http", "current_open"}, nodeData, HTTPConnsMetric, attributes)
-
-		r.processIntMetric([]string{"process", "open_file_descriptors"}, nodeData, OpenFilesMetric, attributes)
-
-		r.processIntMetric([]string{"transport", "server_open"}, nodeData, ServerConnsMetric, attributes)
-
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("index"))
-		r.processIntMetric([]string{"indices", "indexing", "index_total"}, nodeData, OperationsMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("delete"))
-		r.processIntMetric([]string{"indices", "indexing", "delete_total"}, nodeData, OperationsMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("get"))
-		r.processIntMetric([]string{"indices", "get", "total"}, nodeData, OperationsMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("query"))
-		r.processIntMetric([]string{"indices", "search", "query_total"}, nodeData, OperationsMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("fetch"))
-		r.processIntMetric([]string{"indices", "search", "fetch_total"}, nodeData, OperationsMetric, attributes)
-
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("index"))
-		r.processIntMetric([]string{"indices", "indexing", "index_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("delete"))
-		r.processIntMetric([]string{"indices", "indexing", "delete_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("get"))
-		r.processIntMetric([]string{"indices", "get", "time_in_millis"}, nodeData, OperationTimeMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("query"))
-		r.processIntMetric([]string{"indices", "search", "query_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
-		attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("fetch"))
-		r.processIntMetric([]string{"indices", "search", "fetch_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
-		attributes.Delete(metadata.A.Operation)
-
-		r.processIntMetric([]string{"jvm", "threads", "peak_count"}, nodeData, peakThreadsMetric, attributes)
-
-		r.processIntMetric([]string{"indices", "store", "size_in_bytes"}, nodeData, storageSizeMetric, attributes)
-
-		r.processIntMetric([]string{"jvm", "threads", "count"}, nodeData, threadsMetric, attributes)
-
-		threadPools, ok := nodeData["thread_pool"]
+// distribution returns the search engine serving the endpoint. OpenSearch reports itself as the distribution
+// in the root endpoint, which Elasticsearch does not. The distribution is read once, and resources are
+// reported without a distribution until the root endpoint can be read.
+func (r *elasticsearchScraper) distribution(ctx context.Context) string {
+	if r.detectedDistribution != "" {
+		return r.detectedDistribution
+	}
+
+	root, err := r.makeRequest(ctx, "/")
+	if err != nil {
+		r.logger.Warn("failed to read the distribution", zap.Error(err))
+		return ""
+	}
+	distribution, err := getStringFromBody([]string{"version", "distribution"}, root)
+	if err != nil {
+		distribution = distributionElasticsearch
+	}
+	r.detectedDistribution = distribution
+	return distribution
+}
+
+// setClusterResourceAttributes identifies the cluster that a resource's metrics were scraped from.
+func setClusterResourceAttributes(resource pdata.Resource, distribution, clusterName string) {
+	attributes := resource.Attributes()
+	if distribution != "" {
+		attributes.UpsertString(distributionResourceAttribute, distribution)
+	}
+	if clusterName != "" {
+		attributes.UpsertString(clusterNameResourceAttribute, clusterName)
+	}
+}
+
+// setNodeResourceAttributes identifies the node that a resource's metrics were scraped from.
+func setNodeResourceAttributes(resource pdata.Resource, nodeID, version string, nodeData map[string]interface{}) {
+	attributes := resource.Attributes()
+	attributes.UpsertString(nodeIDResourceAttribute, nodeID)
+	if name, err := getStringFromBody([]string{"name"}, nodeData); err == nil {
+		attributes.UpsertString(nodeNameResourceAttribute, name)
+	}
+	if host, err := getStringFromBody([]string{"host"}, nodeData); err == nil {
+		attributes.UpsertString(nodeHostResourceAttribute, host)
+	}
+	if version != "" {
+		attributes.UpsertString(nodeVersionResourceAttribute, version)
+	}
+	if rolesInter, ok := nodeData["roles"].([]interface{}); ok {
+		roles := make([]string, 0, len(rolesInter))
+		for _, role := range rolesInter {
+			if role, ok := role.(string); ok {
+				roles = append(roles, role)
+			}
+		}
+		attributes.UpsertString(nodeRolesResourceAttribute, strings.Join(roles, ","))
+	}
+}
+
+// scrapeNodeMetrics reports the stats of a single node.
+func (r *elasticsearchScraper) scrapeNodeMetrics(nodeData map[string]interface{}, ms pdata.MetricSlice) {
+	cacheMemoryUsageMetric := initMetric(ms, metadata.M.ElasticsearchCacheMemoryUsage).Gauge().DataPoints()
+	evictionsMetric := initMetric(ms, metadata.M.ElasticsearchEvictions).Sum().DataPoints()
+	GCCollectionsMetric := initMetric(ms, metadata.M.ElasticsearchGcCollection).Sum().DataPoints()
+	GCCollectionTimeMetric := initMetric(ms, metadata.M.ElasticsearchGcCollectionTime).Sum().DataPoints()
+	MemoryUsageMetric := initMetric(ms, metadata.M.ElasticsearchMemoryUsage).Gauge().DataPoints()
+	NetworkMetric := initMetric(ms, metadata.M.ElasticsearchNetwork).Sum().DataPoints()
+	CurrentDocsMetric := initMetric(ms, metadata.M.ElasticsearchCurrentDocuments).Gauge().DataPoints()
+	HTTPConnsMetric := initMetric(ms, metadata.M.ElasticsearchHTTPConnections).Gauge().DataPoints()
+	OpenFilesMetric := initMetric(ms, metadata.M.ElasticsearchOpenFiles).Gauge().DataPoints()
+	ServerConnsMetric := initMetric(ms, metadata.M.ElasticsearchServerConnections).Gauge().DataPoints()
+	OperationsMetric := initMetric(ms, metadata.M.ElasticsearchOperations).Sum().DataPoints()
+	OperationTimeMetric := initMetric(ms, metadata.M.ElasticsearchOperationTime).Sum().DataPoints()
+	peakThreadsMetric := initMetric(ms, metadata.M.ElasticsearchPeakThreads).Gauge().DataPoints()
+	storageSizeMetric := initMetric(ms, metadata.M.ElasticsearchStorageSize).Gauge().DataPoints()
+	threadsMetric := initMetric(ms, metadata.M.ElasticsearchThreads).Gauge().DataPoints()
+	breakerEstimatedMetric := initMetric(ms, metadata.M.ElasticsearchBreakerMemoryEstimated).Gauge().DataPoints()
+	breakerLimitMetric := initMetric(ms, metadata.M.ElasticsearchBreakerMemoryLimit).Gauge().DataPoints()
+	breakerTrippedMetric := initMetric(ms, metadata.M.ElasticsearchBreakerTripped).Sum().DataPoints()
+	memoryPoolUsedMetric := initMetric(ms, metadata.M.ElasticsearchMemoryPoolUsed).Gauge().DataPoints()
+	memoryPoolMaxMetric := initMetric(ms, metadata.M.ElasticsearchMemoryPoolMax).Gauge().DataPoints()
+	indexingPressureMemoryMetric := initMetric(ms, metadata.M.ElasticsearchIndexingPressureMemory).Gauge().DataPoints()
+	indexingPressureLimitMetric := initMetric(ms, metadata.M.ElasticsearchIndexingPressureMemoryLimit).Gauge().DataPoints()
+	indexingPressureRejectionsMetric := initMetric(ms, metadata.M.ElasticsearchIndexingPressureRejections).Sum().DataPoints()
+	diskTotalMetric := initMetric(ms, metadata.M.ElasticsearchDiskTotal).Gauge().DataPoints()
+	diskAvailableMetric := initMetric(ms, metadata.M.ElasticsearchDiskAvailable).Gauge().DataPoints()
+	cpuUsageMetric := initMetric(ms, metadata.M.ElasticsearchCPUUsage).Gauge().DataPoints()
+	threadPoolThreadsMetric := initMetric(ms, metadata.M.ElasticsearchThreadPoolThreads).Gauge().DataPoints()
+	threadPoolQueueMetric := initMetric(ms, metadata.M.ElasticsearchThreadPoolQueue).Gauge().DataPoints()
+	threadPoolActiveMetric := initMetric(ms, metadata.M.ElasticsearchThreadPoolActive).Gauge().DataPoints()
+	threadPoolRejectedMetric := initMetric(ms, metadata.M.ElasticsearchThreadPoolRejected).Sum().DataPoints()
+	threadPoolCompletedMetric := initMetric(ms, metadata.M.ElasticsearchThreadPoolCompleted).Sum().DataPoints()
+
+	attributes := pdata.NewAttributeMap()
+
+	attributes.Upsert(metadata.A.CacheName, pdata.NewAttributeValueString("query"))
+	r.processIntMetric([]string{"indices", "query_cache", "memory_size_in_bytes"}, nodeData, cacheMemoryUsageMetric, attributes)
+	r.processIntMetric([]string{"indices", "query_cache", "evictions"}, nodeData, evictionsMetric, attributes)
+	attributes.Upsert(metadata.A.CacheName, pdata.NewAttributeValueString("request"))
+	r.processIntMetric([]string{"indices", "request_cache", "memory_size_in_bytes"}, nodeData, cacheMemoryUsageMetric, attributes)
+	r.processIntMetric([]string{"indices", "request_cache", "evictions"}, nodeData, evictionsMetric, attributes)
+	attributes.Upsert(metadata.A.CacheName, pdata.NewAttributeValueString("field"))
+	r.processIntMetric([]string{"indices", "fielddata", "memory_size_in_bytes"}, nodeData, cacheMemoryUsageMetric, attributes)
+	r.processIntMetric([]string{"indices", "fielddata", "evictions"}, nodeData, evictionsMetric, attributes)
+	attributes.Delete(metadata.A.CacheName)
+
+	attributes.Upsert(metadata.A.GcType, pdata.NewAttributeValueString("young"))
+	r.processIntMetric([]string{"jvm", "gc", "collectors", "young", "collection_count"}, nodeData, GCCollectionsMetric, attributes)
+	r.processIntMetric([]string{"jvm", "gc", "collectors", "young", "collection_time_in_millis"}, nodeData, GCCollectionTimeMetric, attributes)
+	attributes.Upsert(metadata.A.GcType, pdata.NewAttributeValueString("old"))
+	r.processIntMetric([]string{"jvm", "gc", "collectors", "old", "collection_count"}, nodeData, GCCollectionsMetric, attributes)
+	r.processIntMetric([]string{"jvm", "gc", "collectors", "old", "collection_time_in_millis"}, nodeData, GCCollectionTimeMetric, attributes)
+	attributes.Delete(metadata.A.GcType)
+
+	attributes.Upsert(metadata.A.MemoryType, pdata.NewAttributeValueString("heap"))
+	r.processIntMetric([]string{"jvm", "mem", "heap_used_in_bytes"}, nodeData, MemoryUsageMetric, attributes)
+	attributes.Upsert(metadata.A.MemoryType, pdata.NewAttributeValueString("non-heap"))
+	r.processIntMetric([]string{"jvm", "mem", "non_heap_used_in_bytes"}, nodeData, MemoryUsageMetric, attributes)
+	attributes.Delete(metadata.A.MemoryType)
+
+	attributes.Upsert(metadata.A.Direction, pdata.NewAttributeValueString("receive"))
+	r.processIntMetric([]string{"transport", "rx_size_in_bytes"}, nodeData, NetworkMetric, attributes)
+	attributes.Upsert(metadata.A.Direction, pdata.NewAttributeValueString("transmit"))
+	r.processIntMetric([]string{"transport", "tx_size_in_bytes"}, nodeData, NetworkMetric, attributes)
+	attributes.Delete(metadata.A.Direction)
+
+	attributes.Upsert(metadata.A.DocumentType, pdata.NewAttributeValueString("live"))
+	r.processIntMetric([]string{"indices", "docs", "count"}, nodeData, CurrentDocsMetric, attributes)
+	attributes.Upsert(metadata.A.DocumentType, pdata.NewAttributeValueString("deleted"))
+	r.processIntMetric([]string{"indices", "docs", "deleted"}, nodeData, CurrentDocsMetric, attributes)
+	attributes.Delete(metadata.A.DocumentType)
+
+	r.processIntMetric([]string{"http", "current_open"}, nodeData, HTTPConnsMetric, attributes)
+
+	r.processIntMetric([]string{"process", "open_file_descriptors"}, nodeData, OpenFilesMetric, attributes)
+
+	r.processIntMetric([]string{"transport", "server_open"}, nodeData, ServerConnsMetric, attributes)
+
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("index"))
+	r.processIntMetric([]string{"indices", "indexing", "index_total"}, nodeData, OperationsMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("delete"))
+	r.processIntMetric([]string{"indices", "indexing", "delete_total"}, nodeData, OperationsMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("get"))
+	r.processIntMetric([]string{"indices", "get", "total"}, nodeData, OperationsMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("query"))
+	r.processIntMetric([]string{"indices", "search", "query_total"}, nodeData, OperationsMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("fetch"))
+	r.processIntMetric([]string{"indices", "search", "fetch_total"}, nodeData, OperationsMetric, attributes)
+
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("index"))
+	r.processIntMetric([]string{"indices", "indexing", "index_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("delete"))
+	r.processIntMetric([]string{"indices", "indexing", "delete_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("get"))
+	r.processIntMetric([]string{"indices", "get", "time_in_millis"}, nodeData, OperationTimeMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("query"))
+	r.processIntMetric([]string{"indices", "search", "query_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
+	attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString("fetch"))
+	r.processIntMetric([]string{"indices", "search", "fetch_time_in_millis"}, nodeData, OperationTimeMetric, attributes)
+	attributes.Delete(metadata.A.Operation)
+
+	r.processIntMetric([]string{"jvm", "threads", "peak_count"}, nodeData, peakThreadsMetric, attributes)
+
+	r.processIntMetric([]string{"indices", "store", "size_in_bytes"}, nodeData, storageSizeMetric, attributes)
+
+	r.processIntMetric([]string{"jvm", "threads", "count"}, nodeData, threadsMetric, attributes)
+
+	r.processIntMetric([]string{"fs", "total", "total_in_bytes"}, nodeData, diskTotalMetric, attributes)
+	r.processIntMetric([]string{"fs", "total", "available_in_bytes"}, nodeData, diskAvailableMetric, attributes)
+
+	r.processIntMetric([]string{"os", "cpu", "percent"}, nodeData, cpuUsageMetric, attributes)
+
+	// indexing pressure is reported from Elasticsearch 7.9. OpenSearch tracks it per shard instead of per node
+	// while shard indexing pressure is enabled, so the shards of the node are summed up then.
+	if r.shardIndexingPressureEnabled(nodeData) {
+		r.processIntMetric([]string{"indexing_pressure", "memory", "limit_in_bytes"}, nodeData, indexingPressureLimitMetric, attributes)
+		r.processShardIndexingPressure(nodeData, indexingPressureMemoryMetric, indexingPressureRejectionsMetric)
+	} else if _, ok := nodeData["indexing_pressure"]; ok {
+		r.processIntMetric([]string{"indexing_pressure", "memory", "limit_in_bytes"}, nodeData, indexingPressureLimitMetric, attributes)
+		attributes.Upsert(metadata.A.IndexingPressureStage, pdata.NewAttributeValueString(metadata.AttributeIndexingPressureStage.Coordinating))
+		r.processIntMetric([]string{"indexing_pressure", "memory", "current", "coordinating_in_bytes"}, nodeData, indexingPressureMemoryMetric, attributes)
+		r.processIntMetric([]string{"indexing_pressure", "memory", "total", "coordinating_rejections"}, nodeData, indexingPressureRejectionsMetric, attributes)
+		attributes.Upsert(metadata.A.IndexingPressureStage, pdata.NewAttributeValueString(metadata.AttributeIndexingPressureStage.Primary))
+		r.processIntMetric([]string{"indexing_pressure", "memory", "current", "primary_in_bytes"}, nodeData, indexingPressureMemoryMetric, attributes)
+		r.processIntMetric([]string{"indexing_pressure", "memory", "total", "primary_rejections"}, nodeData, indexingPressureRejectionsMetric, attributes)
+		attributes.Upsert(metadata.A.IndexingPressureStage, pdata.NewAttributeValueString(metadata.AttributeIndexingPressureStage.Replica))
+		r.processIntMetric([]string{"indexing_pressure", "memory", "current", "replica_in_bytes"}, nodeData, indexingPressureMemoryMetric, attributes)
+		r.processIntMetric([]string{"indexing_pressure", "memory", "total", "replica_rejections"}, nodeData, indexingPressureRejectionsMetric, attributes)
+		attributes.Delete(metadata.A.IndexingPressureStage)
+	}
+
+	breakers, err := getMapFromBody([]string{"breakers"}, nodeData)
+	if err != nil {
+		r.logger.Info(err.Error())
+	}
+	for breakerName, breakerInter := range breakers {
+		breaker, ok := breakerInter.(map[string]interface{})
 		if !ok {
-			r.logger.Error("no thread pool data available")
+			r.logger.Error("could not reflect circuit breaker data as a map")
 			continue
 		}
-		threadPoolsInter, ok := threadPools.(map[string]interface{})
+		attributes.Upsert(metadata.A.CircuitBreakerName, pdata.NewAttributeValueString(breakerName))
+		r.processIntMetric([]string{"estimated_size_in_bytes"}, breaker, breakerEstimatedMetric, attributes)
+		r.processIntMetric([]string{"limit_size_in_bytes"}, breaker, breakerLimitMetric, attributes)
+		r.processIntMetric([]string{"tripped"}, breaker, breakerTrippedMetric, attributes)
+		attributes.Delete(metadata.A.CircuitBreakerName)
+	}
+
+	memoryPools, err := getMapFromBody([]string{"jvm", "mem", "pools"}, nodeData)
+	if err != nil {
+		r.logger.Info(err.Error())
+	}
+	for poolName, poolInter := range memoryPools {
+		pool, ok := poolInter.(map[string]interface{})
 		if !ok {
-			r.logger.Error("could not reflect thread pools data as a map")
+			r.logger.Error("could not reflect memory pool data as a map")
 			continue
 		}
+		attributes.Upsert(metadata.A.MemoryPoolName, pdata.NewAttributeValueString(poolName))
+		r.processIntMetric([]string{"used_in_bytes"}, pool, memoryPoolUsedMetric, attributes)
+		r.processIntMetric([]string{"max_in_bytes"}, pool, memoryPoolMaxMetric, attributes)
+		attributes.Delete(metadata.A.MemoryPoolName)
+	}
+
+	threadPools, ok := nodeData["thread_pool"]
+	if !ok {
+		r.logger.Error("no thread pool data available")
+		return
+	}
+	threadPoolsInter, ok := threadPools.(map[string]interface{})
+	if !ok {
+		r.logger.Error("could not reflect thread pools data as a map")
+		return
+	}
+
+	for threadPoolName, threadPoolInter := range threadPoolsInter {
+		threadPool, ok := threadPoolInter.(map[string]interface{})
+		if !ok {
+			r.logger.Error("could not reflect thread pool data as a map")
+			continue
+		}
+		attributes.Upsert(metadata.A.ThreadPoolName, pdata.NewAttributeValueString(threadPoolName))
+		r.processIntMetric([]string{"threads"}, threadPool, threadPoolThreadsMetric, attributes)
+		r.processIntMetric([]string{"queue"}, threadPool, threadPoolQueueMetric, attributes)
+		r.processIntMetric([]string{"active"}, threadPool, threadPoolActiveMetric, attributes)
+		r.processIntMetric([]string{"rejected"}, threadPool, threadPoolRejectedMetric, attributes)
+		r.processIntMetric([]string{"completed"}, threadPool, threadPoolCompletedMetric, attributes)
+		attributes.Delete(metadata.A.ThreadPoolName)
+	}
+}
+
+// indexingPressureStages maps the stages of OpenSearch shard indexing pressure to the indexing_pressure_stage attribute.
+var indexingPressureStages = map[string]string{
+	"coordinating": metadata.AttributeIndexingPressureStage.Coordinating,
+	"primary":      metadata.AttributeIndexingPressureStage.Primary,
+	"replica":      metadata.AttributeIndexingPressureStage.Replica,
+}
+
+// shardIndexingPressureEnabled reports whether an OpenSearch node tracks indexing pressure per shard.
+func (r *elasticsearchScraper) shardIndexingPressureEnabled(nodeData map[string]interface{}) bool {
+	shardIndexingPressure, err := getMapFromBody([]string{"shard_indexing_pressure"}, nodeData)
+	if err != nil {
+		return false
+	}
+	enabled, _ := shardIndexingPressure["enabled"].(bool)
+	return enabled
+}
 
-		for threadPoolName, threadPoolInter := range threadPoolsInter {
-			threadPool, ok := threadPoolInter.(map[string]interface{})
-			if !ok {
-				r.logger.Error("could not reflect thread pool data as a map")
-				continue
+// processShardIndexingPressure sums up the indexing pressure of the shards of an OpenSearch node by stage.
+func (r *elasticsearchScraper) processShardIndexingPressure(nodeData map[string]interface{}, memoryMetric, rejectionsMetric pdata.NumberDataPointSlice) {
+	shards, err := getMapFromBody([]string{"shard_indexing_pressure", "stats"}, nodeData)
+	if err != nil {
+		r.logger.Debug(err.Error())
+	}
+
+	memory := map[string]int64{}
+	rejections := map[string]int64{}
+	for _, shardInter := range shards {
+		shard, ok := shardInter.(map[string]interface{})
+		if !ok {
+			r.logger.Info("could not reflect shard indexing pressure as a map")
+			continue
+		}
+		for stage := range indexingPressureStages {
+			if value, err := getIntFromBody([]string{"memory", "current", stage + "_in_bytes"}, shard); err == nil {
+				memory[stage] += value
+			}
+			if value, err := getIntFromBody([]string{"rejection", stage, stage + "_rejections"}, shard); err == nil {
+				rejections[stage] += value
 			}
-			attributes.Upsert(metadata.A.ThreadPoolName, pdata.NewAttributeValueString(threadPoolName))
-			r.processIntMetric([]string{"threads"}, threadPool, threadPoolThreadsMetric, attributes)
-			r.processIntMetric([]string{"queue"}, threadPool, threadPoolQueueMetric, attributes)
-			r.processIntMetric([]string{"active"}, threadPool, threadPoolActiveMetric, attributes)
-			r.processIntMetric([]string{"rejected"}, threadPool, threadPoolRejectedMetric, attributes)
-			r.processIntMetric([]string{"completed"}, threadPool, threadPoolCompletedMetric, attributes)
-			attributes.Delete(metadata.A.ThreadPoolName)
 		}
 	}
 
+	attributes := pdata.NewAttributeMap()
+	for stage, attribute := range indexingPressureStages {
+		attributes.Upsert(metadata.A.IndexingPressureStage, pdata.NewAttributeValueString(attribute))
+		addToIntMetric(memoryMetric, attributes, memory[stage], r.now)
+		addToIntMetric(rejectionsMetric, attributes, rejections[stage], r.now)
+	}
+}
+
+// addNodeFailures adds a partial scrape error if some of the requested nodes failed to report their stats.
+func (r *elasticsearchScraper) addNodeFailures(nodeStats map[string]interface{}, errs *scrapererror.ScrapeErrors) {
+	failed, err := getIntFromBody([]string{"_nodes", "failed"}, nodeStats)
+	if err != nil || failed == 0 {
+		return
+	}
+
+	var reasons []string
+	nodesHeader, _ := nodeStats["_nodes"].(map[string]interface{})
+	failures, _ := nodesHeader["failures"].([]interface{})
+	for _, failureInter := range failures {
+		failure, ok := failureInter.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		reason, err := getStringFromBody([]string{"caused_by", "reason"}, failure)
+		if err != nil {
+			reason, err = getStringFromBody([]string{"reason"}, failure)
+		}
+		if err == nil {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	err = fmt.Errorf("%d nodes failed to report stats: %s", failed, strings.Join(reasons, "; "))
+	// how many metrics are missing depends on the failed nodes, so the nodes are counted instead
+	errs.AddPartial(int(failed), err)
+}
+
+// clusterMetricsCount is the number of metrics missing if the cluster metrics cannot be read.
+const clusterMetricsCount = 6
+
+// scrapeClusterMetrics reports the metrics describing the cluster as a whole.
+func (r *elasticsearchScraper) scrapeClusterMetrics(ctx context.Context, ms pdata.MetricSlice) error {
+	dataNodesMetric := initMetric(ms, metadata.M.ElasticsearchDataNodes).Gauge().DataPoints()
+	nodesMetric := initMetric(ms, metadata.M.ElasticsearchNodes).Gauge().DataPoints()
+	shardsMetric := initMetric(ms, metadata.M.ElasticsearchShards).Gauge().DataPoints()
+	clusterHealthMetric := initMetric(ms, metadata.M.ElasticsearchClusterHealth).Gauge().DataPoints()
+	inFlightFetchesMetric := initMetric(ms, metadata.M.ElasticsearchInFlightFetches).Gauge().DataPoints()
+	pendingTasksMetric := initMetric(ms, metadata.M.ElasticsearchPendingTasks).Gauge().DataPoints()
+
 	attributes := pdata.NewAttributeMap()
 
-	clusterStats, err := r.makeRequest("/_cluster/stats")
+	clusterStats, err := r.makeRequest(ctx, "/_cluster/stats")
 	if err != nil {
-		return pdata.Metrics{}, err
+		return err
 	}
-	r.processIntMetric([]string{"nodes", "count", "data"}, clusterStats, DataNodesMetric, attributes)
+	r.processIntMetric([]string{"nodes", "count", "data"}, clusterStats, dataNodesMetric, attributes)
 
-	r.processIntMetric([]string{"nodes", "count", "total"}, clusterStats, NodesMetric, attributes)
+	r.processIntMetric([]string{"nodes", "count", "total"}, clusterStats, nodesMetric, attributes)
 
-	clusterHealth, err := r.makeRequest("/_cluster/health")
+	clusterHealth, err := r.makeRequest(ctx, "/_cluster/health")
 	if err != nil {
-		return pdata.Metrics{}, err
+		return err
 	}
 	attributes.Upsert(metadata.A.ShardType, pdata.NewAttributeValueString("initializing"))
-	r.processIntMetric([]string{"initializing_shards"}, clusterHealth, ShardsMetric, attributes)
+	r.processIntMetric([]string{"initializing_shards"}, clusterHealth, shardsMetric, attributes)
 	attributes.Upsert(metadata.A.ShardType, pdata.NewAttributeValueString("relocating"))
-	r.processIntMetric([]string{"relocating_shards"}, clusterHealth, ShardsMetric, attributes)
+	r.processIntMetric([]string{"relocating_shards"}, clusterHealth, shardsMetric, attributes)
 	attributes.Upsert(metadata.A.ShardType, pdata.NewAttributeValueString("active"))
-	r.processIntMetric([]string{"active_shards"}, clusterHealth, ShardsMetric, attributes)
+	r.processIntMetric([]string{"active_shards"}, clusterHealth, shardsMetric, attributes)
 	attributes.Upsert(metadata.A.ShardType, pdata.NewAttributeValueString("unassigned"))
-	r.processIntMetric([]string{"unassigned_shards"}, clusterHealth, ShardsMetric, attributes)
+	r.processIntMetric([]string{"unassigned_shards"}, clusterHealth, shardsMetric, attributes)
 	attributes.Delete(metadata.A.ShardType)
 
-	return rms, nil
+	status, err := getStringFromBody([]string{"status"}, clusterHealth)
+	if err != nil {
+		r.logger.Info(err.Error())
+	} else {
+		for _, healthStatus := range []string{
+			metadata.AttributeHealthStatus.Green,
+			metadata.AttributeHealthStatus.Yellow,
+			metadata.AttributeHealthStatus.Red,
+		} {
+			attributes.Upsert(metadata.A.HealthStatus, pdata.NewAttributeValueString(healthStatus))
+			addToIntMetric(clusterHealthMetric, attributes, boolToInt(status == healthStatus), r.now)
+		}
+		attributes.Delete(metadata.A.HealthStatus)
+	}
+
+	r.processIntMetric([]string{"number_of_in_flight_fetch"}, clusterHealth, inFlightFetchesMetric, attributes)
+
+	pendingTasks, err := r.makeRequest(ctx, "/_cluster/pending_tasks")
+	if err != nil {
+		return err
+	}
+	r.processPendingTasks(pendingTasks, pendingTasksMetric)
+
+	return nil
+}
+
+// processPendingTasks counts the pending cluster tasks by priority. Every priority is reported, so that
+// the count drops back to 0 once the tasks of a priority have been executed.
+func (r *elasticsearchScraper) processPendingTasks(body map[string]interface{}, metric pdata.NumberDataPointSlice) {
+	tasks, ok := body["tasks"].([]interface{})
+	if !ok {
+		r.logger.Info("could not find pending tasks in body")
+		return
+	}
+
+	counts := map[string]int64{}
+	for _, taskInter := range tasks {
+		task, ok := taskInter.(map[string]interface{})
+		if !ok {
+			r.logger.Info("could not reflect pending task as a map")
+			continue
+		}
+		priority, err := getStringFromBody([]string{"priority"}, task)
+		if err != nil {
+			r.logger.Info(err.Error())
+			continue
+		}
+		counts[strings.ToLower(priority)]++
+	}
+
+	attributes := pdata.NewAttributeMap()
+	for _, priority := range []string{
+		metadata.AttributeTaskPriority.Immediate,
+		metadata.AttributeTaskPriority.Urgent,
+		metadata.AttributeTaskPriority.High,
+		metadata.AttributeTaskPriority.Normal,
+		metadata.AttributeTaskPriority.Low,
+		metadata.AttributeTaskPriority.Languid,
+	} {
+		attributes.Upsert(metadata.A.TaskPriority, pdata.NewAttributeValueString(priority))
+		addToIntMetric(metric, attributes, counts[priority], r.now)
+	}
 }
 
 func getStringFromBody(keys []string, body map[string]interface{}) (string, error) {
@@ -290,6 +762,27 @@ func getStringFromBody(keys []string, body map[string]interface{}) (string, erro
 	return stringVal, nil
 }
 
+func getMapFromBody(keys []string, body map[string]interface{}) (map[string]interface{}, error) {
+	var currentValue interface{} = body
+
+	for _, key := range keys {
+		currentBody, ok := currentValue.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("could not find key in body, keys: %s", keys)
+		}
+
+		currentValue, ok = currentBody[key]
+		if !ok {
+			return nil, fmt.Errorf("could not find key in body, keys: %s", keys)
+		}
+	}
+	mapVal, ok := currentValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("could not parse value as map, keys: %s", keys)
+	}
+	return mapVal, nil
+}
+
 func getIntFromBody(keys []string, body map[string]interface{}) (int64, error) {
 	var currentValue interface{} = body
 
@@ -331,6 +824,13 @@ func parseInt(value interface{}) (int64, bool) {
 	return 0, false
 }
 
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func initMetric(ms pdata.MetricSlice, mi metadata.MetricIntf) pdata.Metric {
 	m := ms.AppendEmpty()
 	mi.Init(m)
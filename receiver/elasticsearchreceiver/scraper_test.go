@@ -2,64 +2,400 @@ package elasticsearchreceiver
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/observiq/opentelemetry-components/receiver/elasticsearchreceiver/internal/metadata"
 	"github.com/observiq/opentelemetry-components/receiver/helper"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 )
 
 func TestScraper(t *testing.T) {
-	nodes, err := ioutil.ReadFile("./testdata/nodes.json")
+	elasticsearchMock := newMockServer(t)
+	sc, err := newElasticSearchScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: elasticsearchMock.URL,
+		},
+		Username: "dev",
+		Password: "dev",
+	})
 	require.NoError(t, err)
-	cluster, err := ioutil.ReadFile("./testdata/cluster.json")
+	err = sc.start(context.Background(), componenttest.NewNopHost())
 	require.NoError(t, err)
-	health, err := ioutil.ReadFile("./testdata/health.json")
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraper/expected_metrics.json")
 	require.NoError(t, err)
-	rabbitmqMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		if req.URL.Path == "/_nodes/stats" {
-			rw.WriteHeader(200)
-			_, err = rw.Write(nodes)
-			require.NoError(t, err)
-			return
-		}
-		if req.URL.Path == "/_cluster/stats" {
-			rw.WriteHeader(200)
-			_, err = rw.Write(cluster)
+
+	helper.ScraperTest(t, sc.scrape, expectedFileBytes)
+}
+
+func TestScraperIndices(t *testing.T) {
+	elasticsearchMock := newMockServer(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	cfg.Indices.Enabled = true
+	cfg.Indices.Exclude = append(cfg.Indices.Exclude, "logs-*")
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraperindices/expected_metrics.json")
+	require.NoError(t, err)
+
+	helper.ScraperTest(t, sc.scrape, expectedFileBytes)
+}
+
+func TestScraperLocalNode(t *testing.T) {
+	elasticsearchMock := newMockServer(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	cfg.Nodes = []string{"_local"}
+	cfg.SkipClusterMetrics = true
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraperlocalnode/expected_metrics.json")
+	require.NoError(t, err)
+
+	helper.ScraperTest(t, sc.scrape, expectedFileBytes)
+}
+
+func TestScraperLifecycle(t *testing.T) {
+	elasticsearchMock := newMockServer(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	cfg.Snapshots.Enabled = true
+	cfg.ILM.Enabled = true
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraperlifecycle/expected_metrics.json")
+	require.NoError(t, err)
+	helper.ScraperTest(t, sc.scrape, expectedFileBytes)
+}
+
+func TestScraperLifecycleForbidden(t *testing.T) {
+	recorded := newRecordedHandler(t, "./testdata")
+	elasticsearchMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/_slm/stats" {
+			rw.WriteHeader(403)
+			_, err := rw.Write([]byte(`{"error":{"type":"security_exception","reason":"action [cluster:admin/slm/stats] is unauthorized for user [otel]"},"status":403}`))
 			require.NoError(t, err)
 			return
 		}
+		recorded.ServeHTTP(rw, req)
+	}))
+	defer elasticsearchMock.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	cfg.Snapshots.Enabled = true
+	cfg.ILM.Enabled = true
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	md, err := sc.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "request to /_slm/stats failed with status 403: security_exception: action [cluster:admin/slm/stats] is unauthorized for user [otel]")
+	require.NotZero(t, md.MetricCount())
+}
+
+func TestScraperClusterHealthFailed(t *testing.T) {
+	recorded := newRecordedHandler(t, "./testdata")
+	elasticsearchMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.Path == "/_cluster/health" {
-			rw.WriteHeader(200)
-			_, err = rw.Write(health)
-			require.NoError(t, err)
+			rw.WriteHeader(503)
 			return
 		}
-		rw.WriteHeader(404)
+		recorded.ServeHTTP(rw, req)
 	}))
-	sc, err := newElasticSearchScraper(zap.NewNop(), &Config{
-		HTTPClientSettings: confighttp.HTTPClientSettings{
-			Endpoint: rabbitmqMock.URL,
-		},
-		Username: "dev",
-		Password: "dev",
-	})
+	defer elasticsearchMock.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	cfg.Indices.Enabled = true
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
 	require.NoError(t, err)
 	err = sc.start(context.Background(), componenttest.NewNopHost())
 	require.NoError(t, err)
 
-	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraper/expected_metrics.json")
+	md, err := sc.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.NotZero(t, md.MetricCount())
+}
+
+func TestScraperOpenSearch(t *testing.T) {
+	opensearchMock := newRecordedServer(t, "./testdata/opensearch")
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = opensearchMock.URL
+	cfg.Snapshots.Enabled = true
+	cfg.ILM.Enabled = true
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
 	require.NoError(t, err)
 
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraperopensearch/expected_metrics.json")
+	require.NoError(t, err)
 	helper.ScraperTest(t, sc.scrape, expectedFileBytes)
 }
 
+func TestScraperDistributionReadOnce(t *testing.T) {
+	recorded := newRecordedHandler(t, "./testdata/opensearch")
+	rootRequests := 0
+	opensearchMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/" {
+			rootRequests++
+		}
+		recorded.ServeHTTP(rw, req)
+	}))
+	defer opensearchMock.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = opensearchMock.URL
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = sc.scrape(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, rootRequests)
+}
+
+func TestScraperShardIndexingPressure(t *testing.T) {
+	var nodeData map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"shard_indexing_pressure": {
+			"stats": {
+				"[logs][0]": {
+					"memory": {"current": {"coordinating_in_bytes": 10, "primary_in_bytes": 20, "replica_in_bytes": 0}},
+					"rejection": {"coordinating": {"coordinating_rejections": 1}, "primary": {"primary_rejections": 0}, "replica": {"replica_rejections": 2}}
+				},
+				"[logs][1]": {
+					"memory": {"current": {"coordinating_in_bytes": 5, "primary_in_bytes": 0, "replica_in_bytes": 30}},
+					"rejection": {"coordinating": {"coordinating_rejections": 3}, "primary": {"primary_rejections": 0}, "replica": {"replica_rejections": 0}}
+				}
+			},
+			"enabled": true
+		}
+	}`), &nodeData)
+	require.NoError(t, err)
+
+	sc, err := newElasticSearchScraper(zap.NewNop(), NewFactory().CreateDefaultConfig().(*Config))
+	require.NoError(t, err)
+	require.True(t, sc.shardIndexingPressureEnabled(nodeData))
+
+	memoryMetric := pdata.NewNumberDataPointSlice()
+	rejectionsMetric := pdata.NewNumberDataPointSlice()
+	sc.processShardIndexingPressure(nodeData, memoryMetric, rejectionsMetric)
+
+	valuesByStage := func(metric pdata.NumberDataPointSlice) map[string]int64 {
+		values := map[string]int64{}
+		for i := 0; i < metric.Len(); i++ {
+			stage, _ := metric.At(i).Attributes().Get(metadata.A.IndexingPressureStage)
+			values[stage.StringVal()] = metric.At(i).IntVal()
+		}
+		return values
+	}
+	require.Equal(t, map[string]int64{"coordinating": 15, "primary": 20, "replica": 30}, valuesByStage(memoryMetric))
+	require.Equal(t, map[string]int64{"coordinating": 4, "primary": 0, "replica": 2}, valuesByStage(rejectionsMetric))
+}
+
+func TestScraperHTTPError(t *testing.T) {
+	elasticsearchMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(401)
+		_, err := rw.Write([]byte(`{"error":{"root_cause":[{"type":"security_exception","reason":"unable to authenticate user [dev] for REST request [/_nodes/stats]"}],"type":"security_exception","reason":"unable to authenticate user [dev] for REST request [/_nodes/stats]"},"status":401}`))
+		require.NoError(t, err)
+	}))
+	defer elasticsearchMock.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	_, err = sc.scrape(context.Background())
+	var httpErr *httpError
+	require.True(t, errors.As(err, &httpErr))
+	require.Equal(t, 401, httpErr.statusCode)
+	require.EqualError(t, err, "request to /_nodes/stats failed with status 401: security_exception: unable to authenticate user [dev] for REST request [/_nodes/stats]")
+}
+
+func TestScraperAuthorization(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		setup    func(cfg *Config)
+		expected string
+	}{
+		{
+			desc:     "no credentials",
+			setup:    func(cfg *Config) {},
+			expected: "",
+		},
+		{
+			desc: "basic auth",
+			setup: func(cfg *Config) {
+				cfg.Username = "dev"
+				cfg.Password = "dev"
+			},
+			expected: "Basic ZGV2OmRldg==",
+		},
+		{
+			desc: "api key id and key",
+			setup: func(cfg *Config) {
+				cfg.APIKey = "VuaCfGcBCdbkQm-e5aOx:ui2lp2axTNmsyakw9tvNnw"
+			},
+			expected: "ApiKey VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==",
+		},
+		{
+			desc: "encoded api key",
+			setup: func(cfg *Config) {
+				cfg.APIKey = "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw=="
+			},
+			expected: "ApiKey VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==",
+		},
+		{
+			desc: "bearer token",
+			setup: func(cfg *Config) {
+				cfg.BearerToken = "dGhpcyBpcyBub3QgYSByZWFsIHRva2Vu"
+			},
+			expected: "Bearer dGhpcyBpcyBub3QgYSByZWFsIHRva2Vu",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var authorization string
+			elasticsearchMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				authorization = req.Header.Get("Authorization")
+				rw.WriteHeader(401)
+			}))
+			defer elasticsearchMock.Close()
+
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Endpoint = elasticsearchMock.URL
+			tC.setup(cfg)
+			require.NoError(t, cfg.Validate())
+			sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+			require.NoError(t, err)
+			err = sc.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			_, err = sc.scrape(context.Background())
+			require.Error(t, err)
+			require.Equal(t, tC.expected, authorization)
+		})
+	}
+}
+
+func TestScraperNodeFailures(t *testing.T) {
+	elasticsearchMock := newMockServer(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	cfg.Nodes = []string{"data:true"}
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	md, err := sc.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.EqualError(t, err, "1 nodes failed to report stats: [es-node-2][172.18.0.3:9300] Node not connected")
+	require.NotZero(t, md.MetricCount())
+}
+
+func TestScraperCanceledContext(t *testing.T) {
+	elasticsearchMock := newMockServer(t)
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = elasticsearchMock.URL
+	sc, err := newElasticSearchScraper(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = sc.scrape(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// newMockServer serves the recorded Elasticsearch responses in testdata by request path.
+func newMockServer(t *testing.T) *httptest.Server {
+	return newRecordedServer(t, "./testdata")
+}
+
+// newRecordedServer serves the recorded responses in dir by request path.
+func newRecordedServer(t *testing.T, dir string) *httptest.Server {
+	server := httptest.NewServer(newRecordedHandler(t, dir))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newRecordedHandler serves the recorded responses in dir by request path. Requests for responses that
+// are not recorded fail with 404.
+func newRecordedHandler(t *testing.T, dir string) http.Handler {
+	responses := map[string]string{
+		"/":                       "root.json",
+		"/_nodes":                 "nodes_info.json",
+		"/_nodes/_local":          "nodes_info.json",
+		"/_nodes/data:true":       "nodes_info.json",
+		"/_nodes/stats":           "nodes.json",
+		"/_nodes/_local/stats":    "nodes.json",
+		"/_nodes/data:true/stats": "nodes_failed.json",
+		"/_cluster/stats":         "cluster.json",
+		"/_cluster/health":        "health.json",
+		"/_cluster/pending_tasks": "pending_tasks.json",
+		"/*,-.*,-logs-*/_stats/docs,store,indexing,get,search,segments": "index_stats.json",
+		"/_cat/indices/*,-.*,-logs-*":                                   "cat_indices.json",
+		"/_cat/snapshots/backups":                                       "cat_snapshots_backups.json",
+		"/_cat/snapshots/s3-archive":                                    "cat_snapshots_s3-archive.json",
+		"/_cat/snapshots/s3-nightly":                                    "cat_snapshots_s3-nightly.json",
+		"/_snapshot/_all":                                               "snapshot_repositories.json",
+		"/_slm/stats":                                                   "slm_stats.json",
+		"/_slm/policy":                                                  "slm_policy.json",
+		"/_all/_ilm/explain":                                            "ilm_explain.json",
+	}
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		file, ok := responses[req.URL.Path]
+		if !ok {
+			rw.WriteHeader(404)
+			return
+		}
+		body, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			rw.WriteHeader(404)
+			return
+		}
+		require.NoError(t, err)
+		rw.WriteHeader(200)
+		_, err = rw.Write(body)
+		require.NoError(t, err)
+	})
+}
+
 func TestScraperFailedStart(t *testing.T) {
 	sc, err := newElasticSearchScraper(zap.NewNop(), &Config{
 		HTTPClientSettings: confighttp.HTTPClientSettings{
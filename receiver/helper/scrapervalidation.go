@@ -20,10 +20,20 @@ func ScraperTest(t *testing.T, actualScraper scraperhelper.ScrapeFunc, expectedF
 	expectedMetrics, err := unmarshaller.UnmarshalMetrics(expectedFileBytes)
 	require.NoError(t, err)
 
-	eMetricSlice := expectedMetrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
-	aMetricSlice := scrapedRMS.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	eResourceMetrics := expectedMetrics.ResourceMetrics()
+	aResourceMetrics := scrapedRMS.ResourceMetrics()
+	require.Equal(t, eResourceMetrics.Len(), aResourceMetrics.Len(), "resource metrics not of same length")
 
-	require.NoError(t, CompareMetrics(eMetricSlice, aMetricSlice))
+	for i := 0; i < eResourceMetrics.Len(); i++ {
+		eResource := eResourceMetrics.At(i)
+		aResource := aResourceMetrics.At(i)
+		require.Equal(t, eResource.Resource().Attributes().AsRaw(), aResource.Resource().Attributes().AsRaw())
+
+		eMetricSlice := eResource.InstrumentationLibraryMetrics().At(0).Metrics()
+		aMetricSlice := aResource.InstrumentationLibraryMetrics().At(0).Metrics()
+
+		require.NoError(t, CompareMetrics(eMetricSlice, aMetricSlice))
+	}
 }
 
 // CompareMetrics Compares two pdata metric slices to ensure all parts are equal excluding timestamps
@@ -2,7 +2,6 @@ package mongodbreceiver
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,7 +13,9 @@ import (
 )
 
 type client interface {
-	query(context.Context, string, bson.M) (bson.M, error)
+	query(context.Context, string, bson.D) (bson.M, error)
+	aggregate(context.Context, string, string, mongo.Pipeline) ([]bson.M, error)
+	listCollectionNames(context.Context, string) ([]string, error)
 	ListDatabaseNames(context.Context, interface{}, ...*options.ListDatabasesOptions) ([]string, error)
 	Disconnect(context.Context) error
 	Connect(context.Context) error
@@ -32,14 +33,12 @@ type mongodbClient struct {
 type buildClient func(config *Config, logger *zap.Logger) (client, error)
 
 func createClient(config *Config, logger *zap.Logger) (client, error) {
-	authentication := ""
-	if config.Username != "" && config.Password != "" {
-		authentication = fmt.Sprintf("%s:%s@", config.Username, config.Password)
+	clientOptions, err := config.clientOptions()
+	if err != nil {
+		return nil, err
 	}
 
-	uri := fmt.Sprintf("mongodb://%s%s", authentication, config.Endpoint)
-
-	client, err := mongo.NewClient(options.Client().ApplyURI(uri))
+	client, err := mongo.NewClient(clientOptions)
 	return &mongodbClient{
 		Client:  client,
 		logger:  logger,
@@ -47,7 +46,7 @@ func createClient(config *Config, logger *zap.Logger) (client, error) {
 	}, err
 }
 
-func (c *mongodbClient) query(ctx context.Context, database string, command bson.M) (bson.M, error) {
+func (c *mongodbClient) query(ctx context.Context, database string, command bson.D) (bson.M, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 	result := c.Database(database).RunCommand(timeoutCtx, command)
@@ -56,3 +55,30 @@ func (c *mongodbClient) query(ctx context.Context, database string, command bson
 	err := result.Decode(&document)
 	return document, err
 }
+
+func (c *mongodbClient) aggregate(ctx context.Context, database, collection string, pipeline mongo.Pipeline) ([]bson.M, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	var cursor *mongo.Cursor
+	var err error
+	if collection == "" {
+		// database level aggregations such as $currentOp have no collection
+		cursor, err = c.Database(database).Aggregate(timeoutCtx, pipeline)
+	} else {
+		cursor, err = c.Database(database).Collection(collection).Aggregate(timeoutCtx, pipeline)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var documents []bson.M
+	err = cursor.All(timeoutCtx, &documents)
+	return documents, err
+}
+
+// listCollectionNames lists the collections of a database, excluding views.
+func (c *mongodbClient) listCollectionNames(ctx context.Context, database string) ([]string, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.Database(database).ListCollectionNames(timeoutCtx, bson.D{{Key: "type", Value: "collection"}})
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.uber.org/zap"
@@ -13,56 +14,162 @@ import (
 
 var _ client = (*fakeClient)(nil)
 
-type fakeClient struct{}
+type fakeClient struct {
+	mongos bool
+	// member is the host of the replica set member connected to, if any.
+	member string
+}
 
 func createFakeClient(config *Config, logger *zap.Logger) (client, error) {
 	return &fakeClient{}, nil
 }
 
+// createFakeMongosClient acts as a mongos router when connecting to the configured endpoint
+// and as a replica set member when connecting to one of its shards.
+func createFakeMongosClient(config *Config, logger *zap.Logger) (client, error) {
+	return &fakeClient{mongos: config.seedList == nil}, nil
+}
+
+// createFakeReplicaSetClient acts as a direct connection to the member of the replica set in
+// testdata/replsetgetstatus.json that the config connects to.
+func createFakeReplicaSetClient(config *Config, logger *zap.Logger) (client, error) {
+	return &fakeClient{member: config.seedList[0]}, nil
+}
+
 func (c *fakeClient) Disconnect(context.Context) error {
 	return nil
 }
 
-func (c *fakeClient) query(ctx context.Context, database string, command bson.M) (bson.M, error) {
-	var doc bson.M
+func (c *fakeClient) query(ctx context.Context, database string, command bson.D) (bson.M, error) {
+	if c.mongos {
+		return c.mongosQuery(database, command)
+	}
+
 	if database == "admin" {
-		admin, err := ioutil.ReadFile("./testdata/admin.json")
-		if err != nil {
-			return nil, err
-		}
-		err = bson.UnmarshalExtJSON(admin, true, &doc)
-		if err != nil {
-			return nil, err
+		switch command[0].Key {
+		case "isMaster":
+			if c.member != "" {
+				return c.memberIsMaster()
+			}
+			return readDocument("./testdata/ismaster.json")
+		case "replSetGetStatus":
+			if c.member != "" {
+				return c.memberReplSetGetStatus()
+			}
+			return readDocument("./testdata/replsetgetstatus.json")
+		case "top":
+			return readDocument("./testdata/top.json")
+		default:
+			return readDocument("./testdata/admin.json")
 		}
-		return doc, nil
-	} else {
-		for k := range command {
-			if k == "dbStats" {
-				dbStats, err := ioutil.ReadFile("./testdata/dbstats.json")
-				if err != nil {
-					return nil, err
-				}
-				err = bson.UnmarshalExtJSON(dbStats, true, &doc)
-				if err != nil {
-					return nil, err
-				}
-				return doc, nil
-			} else if k == "serverStatus" {
-				serverStatus, err := ioutil.ReadFile("./testdata/serverstatus.json")
-				if err != nil {
-					return nil, err
-				}
-				err = bson.UnmarshalExtJSON(serverStatus, true, &doc)
-				if err != nil {
-					return nil, err
-				}
-				return doc, nil
+	}
+
+	switch command[0].Key {
+	case "dbStats":
+		return readDocument("./testdata/dbstats.json")
+	case "collStats":
+		return readDocument("./testdata/collstats.json")
+	case "find":
+		if command[0].Value == "oplog.rs" && database == "local" {
+			sort := command.Map()["sort"].(bson.D)
+			if sort[0].Value == 1 {
+				return readDocument("./testdata/oplogfirst.json")
 			}
+			return readDocument("./testdata/oploglast.json")
 		}
 	}
 	return nil, fmt.Errorf("document could not be found")
 }
 
+// memberReplSetGetStatus marks the member that the client is connected to as self.
+func (c *fakeClient) memberReplSetGetStatus() (bson.M, error) {
+	doc, err := readDocument("./testdata/replsetgetstatus.json")
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range doc["members"].(bson.A) {
+		member := m.(bson.M)
+		member["self"] = member["name"] == c.member
+	}
+	return doc, nil
+}
+
+func (c *fakeClient) memberIsMaster() (bson.M, error) {
+	doc, err := readDocument("./testdata/replsetismaster.json")
+	if err != nil {
+		return nil, err
+	}
+	doc["me"] = c.member
+	doc["ismaster"] = c.member == doc["primary"]
+	doc["secondary"] = c.member != doc["primary"]
+	return doc, nil
+}
+
+func (c *fakeClient) mongosQuery(database string, command bson.D) (bson.M, error) {
+	if database == "admin" {
+		switch command[0].Key {
+		case "isMaster":
+			return readDocument("./testdata/mongos/ismaster.json")
+		case "listShards":
+			return readDocument("./testdata/mongos/listshards.json")
+		case "balancerStatus":
+			return readDocument("./testdata/mongos/balancerstatus.json")
+		case "serverStatus":
+			return readDocument("./testdata/mongos/serverstatus.json")
+		}
+	}
+	return nil, fmt.Errorf("document could not be found")
+}
+
+func (c *fakeClient) aggregate(ctx context.Context, database, collection string, pipeline mongo.Pipeline) ([]bson.M, error) {
+	if c.mongos && database == "config" && collection == "chunks" {
+		return readDocuments("./testdata/mongos/chunks.json")
+	}
+	if database == "admin" && pipeline[0][0].Key == "$currentOp" {
+		return readDocuments("./testdata/currentop.json")
+	}
+	if pipeline[0][0].Key == "$indexStats" {
+		return readDocuments("./testdata/indexstats.json")
+	}
+	return nil, fmt.Errorf("documents could not be found")
+}
+
+func (c *fakeClient) listCollectionNames(ctx context.Context, database string) ([]string, error) {
+	return []string{"orders", "system.profile"}, nil
+}
+
+func readDocuments(path string) ([]bson.M, error) {
+	var doc bson.M
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// extended JSON can only be unmarshaled into a document, so the fixtures wrap their results
+	err = bson.UnmarshalExtJSON(bytes, true, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []bson.M
+	for _, d := range doc["results"].(bson.A) {
+		docs = append(docs, d.(bson.M))
+	}
+	return docs, nil
+}
+
+func readDocument(path string) (bson.M, error) {
+	var doc bson.M
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	err = bson.UnmarshalExtJSON(bytes, true, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
 func (c *fakeClient) ListDatabaseNames(_ context.Context, _ interface{}, _ ...*options.ListDatabasesOptions) ([]string, error) {
 	return []string{"fakedatabase"}, nil
 }
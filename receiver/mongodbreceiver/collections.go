@@ -0,0 +1,104 @@
+package mongodbreceiver
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/observiq/opentelemetry-components/receiver/mongodbreceiver/internal/metadata"
+)
+
+var collStatsMetrics = []mongoMetric{
+	{
+		metricDef:     metadata.M.MongodbCollectionObjects,
+		path:          []string{"count"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbCollectionDataSize,
+		path:          []string{"size"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbCollectionStorageSize,
+		path:          []string{"storageSize"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbCollectionAverageObjectSize,
+		path:          []string{"avgObjSize"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbCollectionIndexSize,
+		path:          []string{"totalIndexSize"},
+		dataPointType: integer,
+	},
+}
+
+var indexStatsPipeline = mongo.Pipeline{
+	{{Key: "$indexStats", Value: bson.D{}}},
+}
+
+// collectCollectionMetrics reports collStats for each collection of the database selected by the
+// collection_stats filter, and $indexStats access counts if index_stats is enabled.
+func (r *mongodbScraper) collectCollectionMetrics(ctx context.Context, client client, mm *metricManager, dbName string) {
+	collectionNames, err := client.listCollectionNames(ctx, dbName)
+	if err != nil {
+		r.logger.Error("Failed to fetch collection names", zap.Error(err), zap.String("database", dbName))
+		return
+	}
+
+	for _, collectionName := range collectionNames {
+		if !r.config.CollectionStats.Collections.matches(collectionName) {
+			continue
+		}
+
+		attributes := pdata.NewAttributeMap()
+		attributes.Insert(metadata.A.DatabaseName, pdata.NewAttributeValueString(dbName))
+		attributes.Insert(metadata.A.CollectionName, pdata.NewAttributeValueString(collectionName))
+
+		collStats, err := client.query(ctx, dbName, bson.D{{Key: "collStats", Value: collectionName}})
+		if err != nil {
+			r.logger.Error("Failed to collect collStats metric", zap.Error(err), zap.String("database", dbName), zap.String("collection", collectionName))
+		} else {
+			r.parseMetrics(mm, attributes, collStatsMetrics, collStats)
+		}
+
+		if r.config.CollectionStats.IndexStats {
+			r.collectIndexMetrics(ctx, client, mm, dbName, collectionName, attributes)
+		}
+	}
+}
+
+func (r *mongodbScraper) collectIndexMetrics(
+	ctx context.Context,
+	client client,
+	mm *metricManager,
+	dbName string,
+	collectionName string,
+	collectionAttributes pdata.AttributeMap,
+) {
+	indexStats, err := client.aggregate(ctx, dbName, collectionName, indexStatsPipeline)
+	if err != nil {
+		r.logger.Error("Failed to collect $indexStats metric", zap.Error(err), zap.String("database", dbName), zap.String("collection", collectionName))
+		return
+	}
+
+	for _, index := range indexStats {
+		accesses, err := getIntMetricValue(index, []string{"accesses", "ops"})
+		if err != nil {
+			r.logger.Error("Failed to Parse", zap.Error(err), zap.String("metric", metadata.M.MongodbIndexAccesses.Name()))
+			continue
+		}
+
+		indexName, _ := index["name"].(string)
+		attributes := pdata.NewAttributeMap()
+		collectionAttributes.CopyTo(attributes)
+		attributes.Insert(metadata.A.IndexName, pdata.NewAttributeValueString(indexName))
+		mm.addIntDataPoint(metadata.M.MongodbIndexAccesses, accesses, attributes)
+	}
+}
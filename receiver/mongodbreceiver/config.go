@@ -2,25 +2,217 @@ package mongodbreceiver
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 	confignet.TCPAddr                       `mapstructure:",squash"`
-	Username                                string        `mapstructure:"username"`
-	Password                                string        `mapstructure:"password"`
-	Timeout                                 time.Duration `mapstructure:"timeout"`
+	// Hosts are scraped independently of each other, each under a resource of its own.
+	// When set, they are used instead of the endpoint and the hosts of the uri.
+	Hosts []HostConfig `mapstructure:"hosts"`
+	// URI is a MongoDB connection string. When set, it is used instead of the endpoint.
+	URI           string                     `mapstructure:"uri"`
+	Username      string                     `mapstructure:"username"`
+	Password      string                     `mapstructure:"password"`
+	AuthMechanism string                     `mapstructure:"auth_mechanism"`
+	AuthSource    string                     `mapstructure:"auth_source"`
+	TLS           configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+	Timeout       time.Duration              `mapstructure:"timeout"`
+	// Databases selects the databases that dbStats, collection stats and top metrics are collected from.
+	// Collection stats are further limited to the databases selected by CollectionStats.Databases.
+	Databases       FilterConfig          `mapstructure:"databases"`
+	CollectionStats CollectionStatsConfig `mapstructure:"collection_stats"`
+	Operations      OperationsConfig      `mapstructure:"operations"`
+
+	// seedList and replicaSet replace the hosts of the uri or endpoint on configs derived for one of
+	// the configured hosts or for the shards of a sharded cluster.
+	seedList         []string
+	replicaSet       string
+	directConnection bool
+}
+
+// HostConfig is a server to scrape.
+type HostConfig struct {
+	confignet.TCPAddr `mapstructure:",squash"`
+	// DirectConnection connects to the host itself rather than discovering the replica set it is a member of,
+	// so that secondaries are scraped instead of the primary.
+	DirectConnection bool `mapstructure:"direct_connection"`
+}
+
+// authMechanisms are the authentication mechanisms supported by the driver.
+var authMechanisms = map[string]bool{
+	"SCRAM-SHA-1":   true,
+	"SCRAM-SHA-256": true,
+	"MONGODB-X509":  true,
+	"MONGODB-AWS":   true,
+	"GSSAPI":        true,
+	"PLAIN":         true,
+}
+
+// CollectionStatsConfig configures the collection of per-collection and per-index statistics.
+type CollectionStatsConfig struct {
+	Enabled     bool         `mapstructure:"enabled"`
+	IndexStats  bool         `mapstructure:"index_stats"`
+	Databases   FilterConfig `mapstructure:"databases"`
+	Collections FilterConfig `mapstructure:"collections"`
+}
+
+// OperationsConfig configures the collection of metrics about the operations running on the server.
+type OperationsConfig struct {
+	// CurrentOp enables metrics about in progress operations from $currentOp.
+	CurrentOp bool `mapstructure:"current_op"`
+	// Top enables per-collection operation latency from the top command.
+	Top bool `mapstructure:"top"`
+}
+
+// FilterConfig selects names using glob patterns. A name is selected if it matches
+// any include pattern, or there are none, and does not match any exclude pattern.
+type FilterConfig struct {
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
 }
 
 func (c *Config) Validate() error {
-	if c.Username != "" && c.Password == "" {
+	if c.AuthMechanism != "" && !authMechanisms[c.AuthMechanism] {
+		return fmt.Errorf("unsupported auth_mechanism %q", c.AuthMechanism)
+	}
+
+	if c.AuthMechanism == "MONGODB-X509" {
+		// the client certificate is the credential, the user is optional
+		if c.Password != "" {
+			return errors.New("password provided with MONGODB-X509 auth_mechanism")
+		}
+	} else if c.Username != "" && c.Password == "" {
 		return errors.New("user provided without password")
 	} else if c.Username == "" && c.Password != "" {
 		return errors.New("password provided without user")
 	}
+
+	// configtls does not load any TLS settings while insecure is set without a ca_file, so the connection
+	// would silently be made in plaintext without the client certificate
+	if c.TLS.Insecure && c.TLS.CAFile == "" {
+		if c.TLS.CertFile != "" || c.TLS.KeyFile != "" {
+			return errors.New("tls cert_file or key_file provided without ca_file while tls insecure is true")
+		}
+		if c.AuthMechanism == "MONGODB-X509" {
+			return errors.New("MONGODB-X509 auth_mechanism requires tls insecure to be false or a tls ca_file")
+		}
+	}
+
+	if c.URI != "" && !strings.HasPrefix(c.URI, "mongodb://") && !strings.HasPrefix(c.URI, "mongodb+srv://") {
+		return errors.New(`uri must start with "mongodb://" or "mongodb+srv://"`)
+	}
+	for _, host := range c.Hosts {
+		if host.Endpoint == "" {
+			return errors.New("hosts must have an endpoint")
+		}
+	}
+	if err := c.Databases.validate(); err != nil {
+		return fmt.Errorf("invalid databases: %w", err)
+	}
+	if err := c.CollectionStats.Databases.validate(); err != nil {
+		return fmt.Errorf("invalid collection_stats databases: %w", err)
+	}
+	if err := c.CollectionStats.Collections.validate(); err != nil {
+		return fmt.Errorf("invalid collection_stats collections: %w", err)
+	}
+	return nil
+}
+
+func (f FilterConfig) validate() error {
+	for _, pattern := range append(f.Include, f.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%q: %w", pattern, err)
+		}
+	}
 	return nil
 }
+
+func (f FilterConfig) matches(name string) bool {
+	if len(f.Include) > 0 && !matchesAny(f.Include, name) {
+		return false
+	}
+	return !matchesAny(f.Exclude, name)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// forShard returns a copy of the config that connects to a shard or config server replica set.
+// connString is in the "replicaSet/host1:port,host2:port" form reported by listShards.
+func (c *Config) forShard(connString string) *Config {
+	shardConfig := *c
+	shardConfig.replicaSet = ""
+	if i := strings.Index(connString, "/"); i >= 0 {
+		shardConfig.replicaSet = connString[:i]
+		connString = connString[i+1:]
+	}
+	shardConfig.seedList = strings.Split(connString, ",")
+	shardConfig.directConnection = false
+	return &shardConfig
+}
+
+// forHost returns a copy of the config that connects to one of the configured hosts.
+func (c *Config) forHost(host HostConfig) *Config {
+	hostConfig := *c
+	hostConfig.Hosts = nil
+	hostConfig.seedList = []string{host.Endpoint}
+	hostConfig.replicaSet = ""
+	hostConfig.directConnection = host.DirectConnection
+	return &hostConfig
+}
+
+// clientOptions builds the driver options for connecting to the configured uri or endpoint.
+func (c *Config) clientOptions() (*options.ClientOptions, error) {
+	uri := c.URI
+	if uri == "" {
+		uri = fmt.Sprintf("mongodb://%s", c.Endpoint)
+	}
+	clientOptions := options.Client().ApplyURI(uri)
+
+	if c.seedList != nil {
+		clientOptions.SetHosts(c.seedList)
+		clientOptions.ReplicaSet = nil
+		if c.replicaSet != "" {
+			clientOptions.SetReplicaSet(c.replicaSet)
+		}
+	}
+	if c.directConnection {
+		clientOptions.SetDirect(true)
+	}
+
+	if c.Username != "" || c.AuthMechanism != "" {
+		clientOptions.SetAuth(options.Credential{
+			AuthMechanism: c.AuthMechanism,
+			AuthSource:    c.AuthSource,
+			Username:      c.Username,
+			Password:      c.Password,
+			PasswordSet:   c.Password != "",
+		})
+	}
+
+	tlsConfig, err := c.TLS.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+
+	return clientOptions, clientOptions.Validate()
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 func TestValidate(t *testing.T) {
@@ -51,3 +52,242 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAuth(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		cfg           Config
+		expectedError string
+	}{
+		{
+			desc: "x509 without user",
+			cfg:  Config{AuthMechanism: "MONGODB-X509"},
+		},
+		{
+			desc:          "x509 with password",
+			cfg:           Config{AuthMechanism: "MONGODB-X509", Username: "CN=otel", Password: "pass"},
+			expectedError: "password provided with MONGODB-X509 auth_mechanism",
+		},
+		{
+			desc: "scram with user and password",
+			cfg:  Config{AuthMechanism: "SCRAM-SHA-256", AuthSource: "admin", Username: "otel", Password: "otel"},
+		},
+		{
+			desc:          "x509 without tls",
+			cfg:           Config{AuthMechanism: "MONGODB-X509", TLS: configtls.TLSClientSetting{Insecure: true}},
+			expectedError: "MONGODB-X509 auth_mechanism requires tls insecure to be false or a tls ca_file",
+		},
+		{
+			desc: "client certificate while insecure",
+			cfg: Config{TLS: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CertFile: "client.crt", KeyFile: "client.key"},
+				Insecure:   true,
+			}},
+			expectedError: "tls cert_file or key_file provided without ca_file while tls insecure is true",
+		},
+		{
+			desc: "ca file while insecure",
+			cfg: Config{TLS: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "ca.crt"},
+				Insecure:   true,
+			}},
+		},
+		{
+			desc: "client certificate with ca file while insecure",
+			cfg: Config{AuthMechanism: "MONGODB-X509", TLS: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "ca.crt", CertFile: "client.crt", KeyFile: "client.key"},
+				Insecure:   true,
+			}},
+		},
+		{
+			desc: "client certificate with tls",
+			cfg: Config{AuthMechanism: "MONGODB-X509", TLS: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "ca.crt", CertFile: "client.crt", KeyFile: "client.key"},
+			}},
+		},
+		{
+			desc:          "unsupported mechanism",
+			cfg:           Config{AuthMechanism: "MONGODB-CR"},
+			expectedError: `unsupported auth_mechanism "MONGODB-CR"`,
+		},
+		{
+			desc: "srv uri",
+			cfg:  Config{URI: "mongodb+srv://cluster0.example.com"},
+		},
+		{
+			desc:          "invalid uri scheme",
+			cfg:           Config{URI: "http://localhost:27017"},
+			expectedError: `uri must start with "mongodb://" or "mongodb+srv://"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.cfg.Validate()
+			if tC.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tC.expectedError)
+			}
+		})
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		clientOptions, err := cfg.clientOptions()
+		require.NoError(t, err)
+		require.Equal(t, []string{"localhost:27017"}, clientOptions.Hosts)
+		require.Nil(t, clientOptions.Auth)
+		require.Nil(t, clientOptions.TLSConfig)
+	})
+
+	t.Run("credentials", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.Username = "otel"
+		cfg.Password = "otel"
+		cfg.AuthSource = "users"
+		cfg.AuthMechanism = "SCRAM-SHA-256"
+		clientOptions, err := cfg.clientOptions()
+		require.NoError(t, err)
+		require.Equal(t, "otel", clientOptions.Auth.Username)
+		require.Equal(t, "users", clientOptions.Auth.AuthSource)
+		require.Equal(t, "SCRAM-SHA-256", clientOptions.Auth.AuthMechanism)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.TLS.Insecure = false
+		cfg.TLS.ServerName = "mongo.example.com"
+		clientOptions, err := cfg.clientOptions()
+		require.NoError(t, err)
+		require.NotNil(t, clientOptions.TLSConfig)
+		require.Equal(t, "mongo.example.com", clientOptions.TLSConfig.ServerName)
+	})
+
+	t.Run("tls missing ca file", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.TLS.CAFile = "/non/existent"
+		_, err := cfg.clientOptions()
+		require.Error(t, err)
+	})
+
+	t.Run("uri", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.URI = "mongodb://mongo-0:27017,mongo-1:27017/?replicaSet=rs0&authSource=admin"
+		clientOptions, err := cfg.clientOptions()
+		require.NoError(t, err)
+		require.Equal(t, []string{"mongo-0:27017", "mongo-1:27017"}, clientOptions.Hosts)
+		require.Equal(t, "rs0", *clientOptions.ReplicaSet)
+	})
+
+	t.Run("shard of uri", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.URI = "mongodb://mongos-0:27017/?tls=true"
+		clientOptions, err := cfg.forShard("shard01/shard01-a:27018,shard01-b:27018").clientOptions()
+		require.NoError(t, err)
+		require.Equal(t, []string{"shard01-a:27018", "shard01-b:27018"}, clientOptions.Hosts)
+		require.Equal(t, "shard01", *clientOptions.ReplicaSet)
+		require.NotNil(t, clientOptions.TLSConfig)
+	})
+
+	t.Run("direct host of uri", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.URI = "mongodb://mongo-0:27017,mongo-1:27017/?replicaSet=rs0&authSource=admin"
+		host := HostConfig{DirectConnection: true}
+		host.Endpoint = "mongo-1:27017"
+		clientOptions, err := cfg.forHost(host).clientOptions()
+		require.NoError(t, err)
+		require.Equal(t, []string{"mongo-1:27017"}, clientOptions.Hosts)
+		require.Nil(t, clientOptions.ReplicaSet)
+		require.True(t, *clientOptions.Direct)
+	})
+}
+
+func TestForShard(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		connString         string
+		expectedHosts      []string
+		expectedReplicaSet string
+	}{
+		{
+			desc:               "replica set",
+			connString:         "shard01/shard01-a:27018,shard01-b:27018",
+			expectedHosts:      []string{"shard01-a:27018", "shard01-b:27018"},
+			expectedReplicaSet: "shard01",
+		},
+		{
+			desc:               "standalone",
+			connString:         "shard01-a:27018",
+			expectedHosts:      []string{"shard01-a:27018"},
+			expectedReplicaSet: "",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := Config{Username: "otel", Password: "otel"}
+			cfg.Endpoint = "mongos:27017"
+
+			shardCfg := cfg.forShard(tC.connString)
+			require.Equal(t, tC.expectedHosts, shardCfg.seedList)
+			require.Equal(t, tC.expectedReplicaSet, shardCfg.replicaSet)
+			require.Equal(t, cfg.Username, shardCfg.Username)
+			require.Nil(t, cfg.seedList)
+		})
+	}
+}
+
+func TestFilterConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filter   FilterConfig
+		name     string
+		expected bool
+	}{
+		{
+			desc:     "no patterns",
+			filter:   FilterConfig{},
+			name:     "orders",
+			expected: true,
+		},
+		{
+			desc:     "included",
+			filter:   FilterConfig{Include: []string{"order*"}},
+			name:     "orders",
+			expected: true,
+		},
+		{
+			desc:     "not included",
+			filter:   FilterConfig{Include: []string{"order*"}},
+			name:     "customers",
+			expected: false,
+		},
+		{
+			desc:     "excluded",
+			filter:   FilterConfig{Exclude: []string{"system.*"}},
+			name:     "system.profile",
+			expected: false,
+		},
+		{
+			desc:     "included and excluded",
+			filter:   FilterConfig{Include: []string{"*"}, Exclude: []string{"orders"}},
+			name:     "orders",
+			expected: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			require.Equal(t, tC.expected, tC.filter.matches(tC.name))
+		})
+	}
+}
+
+func TestValidateFilterPatterns(t *testing.T) {
+	cfg := Config{}
+	cfg.CollectionStats.Collections.Include = []string{"orders["}
+	require.EqualError(t, cfg.Validate(), `invalid collection_stats collections: "orders[": syntax error in pattern`)
+
+	cfg.Databases.Exclude = []string{"tmp["}
+	require.EqualError(t, cfg.Validate(), `invalid databases: "tmp[": syntax error in pattern`)
+}
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -32,7 +33,18 @@ func createDefaultConfig() config.Receiver {
 			ReceiverSettings:   config.NewReceiverSettings(config.NewComponentID(typeStr)),
 			CollectionInterval: 60 * time.Second,
 		},
+		TLS: configtls.TLSClientSetting{
+			Insecure: true,
+		},
 		Timeout: 10 * time.Second,
+		CollectionStats: CollectionStatsConfig{
+			Databases: FilterConfig{
+				Exclude: []string{"admin", "config", "local"},
+			},
+			Collections: FilterConfig{
+				Exclude: []string{"system.*"},
+			},
+		},
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: "localhost:27017",
 		},
@@ -48,7 +48,6 @@ func TestMongoDBIntegration(t *testing.T) {
 	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
 	require.Equal(t, 1, ilms.Len())
 	metrics := ilms.At(0).Metrics()
-	require.Equal(t, 13, metrics.Len())
 	require.NoError(t, rcvr.Shutdown(context.Background()))
 
 	validateResult(t, metrics)
@@ -78,8 +77,31 @@ func getContainer(t *testing.T, req testcontainers.ContainerRequest) testcontain
 	return container
 }
 
+// unreportedMetrics are opt-in metrics and replica set and sharded cluster metrics,
+// which are not reported by the default config against the standalone server used in these tests.
+var unreportedMetrics = []string{
+	metadata.M.MongodbBalancerEnabled.Name(),
+	metadata.M.MongodbBalancerRunning.Name(),
+	metadata.M.MongodbChunks.Name(),
+	metadata.M.MongodbCollectionAverageObjectSize.Name(),
+	metadata.M.MongodbCollectionDataSize.Name(),
+	metadata.M.MongodbCollectionIndexSize.Name(),
+	metadata.M.MongodbCollectionObjects.Name(),
+	metadata.M.MongodbCollectionOperationCount.Name(),
+	metadata.M.MongodbCollectionOperationTime.Name(),
+	metadata.M.MongodbCollectionStorageSize.Name(),
+	metadata.M.MongodbCurrentOperations.Name(),
+	metadata.M.MongodbIndexAccesses.Name(),
+	metadata.M.MongodbLockWaitingOperations.Name(),
+	metadata.M.MongodbLongestOperationAge.Name(),
+	metadata.M.MongodbMemberHealth.Name(),
+	metadata.M.MongodbMemberState.Name(),
+	metadata.M.MongodbOplogWindow.Name(),
+	metadata.M.MongodbReplicationLag.Name(),
+}
+
 func validateResult(t *testing.T, metrics pdata.MetricSlice) {
-	require.Equal(t, len(metadata.M.Names()), metrics.Len())
+	require.Equal(t, len(metadata.M.Names())-len(unreportedMetrics), metrics.Len())
 	exists := make(map[string]bool)
 
 	unenumAttributeSet := []string{
@@ -90,11 +112,22 @@ func validateResult(t *testing.T, metrics pdata.MetricSlice) {
 		metadata.A.MemoryType,
 		metadata.A.Operation,
 		metadata.A.ConnectionType,
+		metadata.A.CacheStatus,
+		metadata.A.EvictionType,
+		metadata.A.TicketType,
+		metadata.A.TicketState,
+		metadata.A.DocumentOperation,
+		metadata.A.LatencyOperation,
+		metadata.A.CursorType,
+		metadata.A.AssertType,
+		metadata.A.Direction,
+		metadata.A.LockClientType,
 	}
 
 	for i := 0; i < metrics.Len(); i++ {
 		m := metrics.At(i)
 		require.Contains(t, metadata.M.Names(), m.Name())
+		require.NotContains(t, unreportedMetrics, m.Name())
 
 		metricIntr := metadata.M.ByName(m.Name())
 		require.Equal(t, metricIntr.New().DataType(), m.DataType())
@@ -128,28 +161,62 @@ func validateResult(t *testing.T, metrics pdata.MetricSlice) {
 	}
 
 	require.Equal(t, map[string]bool{
-		"mongodb.cache_hits":                                   true,
-		"mongodb.cache_misses":                                 true,
-		"mongodb.collections database_name":                    true,
-		"mongodb.connections database_name active":             true,
-		"mongodb.connections database_name available":          true,
-		"mongodb.connections database_name current":            true,
-		"mongodb.data_size database_name":                      true,
-		"mongodb.extents database_name":                        true,
-		"mongodb.global_lock_hold_time":                        true,
-		"mongodb.index_size database_name":                     true,
-		"mongodb.indexes database_name":                        true,
-		"mongodb.memory_usage database_name mapped":            true,
-		"mongodb.memory_usage database_name mappedWithJournal": true,
-		"mongodb.memory_usage database_name resident":          true,
-		"mongodb.memory_usage database_name virtual":           true,
-		"mongodb.objects database_name":                        true,
-		"mongodb.operations command":                           true,
-		"mongodb.operations delete":                            true,
-		"mongodb.operations getmore":                           true,
-		"mongodb.operations insert":                            true,
-		"mongodb.operations query":                             true,
-		"mongodb.operations update":                            true,
-		"mongodb.storage_size database_name":                   true,
+		"mongodb.asserts msg":                        true,
+		"mongodb.asserts regular":                    true,
+		"mongodb.asserts user":                       true,
+		"mongodb.asserts warning":                    true,
+		"mongodb.cache_evictions modified":           true,
+		"mongodb.cache_evictions unmodified":         true,
+		"mongodb.cache_hits":                         true,
+		"mongodb.cache_limit":                        true,
+		"mongodb.cache_misses":                       true,
+		"mongodb.cache_usage dirty":                  true,
+		"mongodb.cache_usage used":                   true,
+		"mongodb.collections database_name":          true,
+		"mongodb.connections active":                 true,
+		"mongodb.connections available":              true,
+		"mongodb.connections current":                true,
+		"mongodb.cursor_timeouts":                    true,
+		"mongodb.cursors noTimeout":                  true,
+		"mongodb.cursors pinned":                     true,
+		"mongodb.cursors total":                      true,
+		"mongodb.data_size database_name":            true,
+		"mongodb.document_operations deleted":        true,
+		"mongodb.document_operations inserted":       true,
+		"mongodb.document_operations returned":       true,
+		"mongodb.document_operations updated":        true,
+		"mongodb.extents database_name":              true,
+		"mongodb.global_lock_active_clients readers": true,
+		"mongodb.global_lock_active_clients writers": true,
+		"mongodb.global_lock_hold_time":              true,
+		"mongodb.global_lock_queue readers":          true,
+		"mongodb.global_lock_queue writers":          true,
+		"mongodb.index_size database_name":           true,
+		"mongodb.indexes database_name":              true,
+		"mongodb.memory_usage mapped":                true,
+		"mongodb.memory_usage mappedWithJournal":     true,
+		"mongodb.memory_usage resident":              true,
+		"mongodb.memory_usage virtual":               true,
+		"mongodb.network_io received":                true,
+		"mongodb.network_io transmitted":             true,
+		"mongodb.network_requests":                   true,
+		"mongodb.objects database_name":              true,
+		"mongodb.operation_latency_count commands":   true,
+		"mongodb.operation_latency_count reads":      true,
+		"mongodb.operation_latency_count writes":     true,
+		"mongodb.operation_latency_time commands":    true,
+		"mongodb.operation_latency_time reads":       true,
+		"mongodb.operation_latency_time writes":      true,
+		"mongodb.operations command":                 true,
+		"mongodb.operations delete":                  true,
+		"mongodb.operations getmore":                 true,
+		"mongodb.operations insert":                  true,
+		"mongodb.operations query":                   true,
+		"mongodb.operations update":                  true,
+		"mongodb.storage_size database_name":         true,
+		"mongodb.tickets read available":             true,
+		"mongodb.tickets read out":                   true,
+		"mongodb.tickets write available":            true,
+		"mongodb.tickets write out":                  true,
 	}, exists)
 }
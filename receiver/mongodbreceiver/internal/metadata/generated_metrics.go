@@ -41,54 +41,150 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	MongodbCacheHits          MetricIntf
-	MongodbCacheMisses        MetricIntf
-	MongodbCollections        MetricIntf
-	MongodbConnections        MetricIntf
-	MongodbDataSize           MetricIntf
-	MongodbExtents            MetricIntf
-	MongodbGlobalLockHoldTime MetricIntf
-	MongodbIndexSize          MetricIntf
-	MongodbIndexes            MetricIntf
-	MongodbMemoryUsage        MetricIntf
-	MongodbObjects            MetricIntf
-	MongodbOperations         MetricIntf
-	MongodbStorageSize        MetricIntf
+	MongodbAsserts                     MetricIntf
+	MongodbBalancerEnabled             MetricIntf
+	MongodbBalancerRunning             MetricIntf
+	MongodbCacheEvictions              MetricIntf
+	MongodbCacheHits                   MetricIntf
+	MongodbCacheLimit                  MetricIntf
+	MongodbCacheMisses                 MetricIntf
+	MongodbCacheUsage                  MetricIntf
+	MongodbChunks                      MetricIntf
+	MongodbCollectionAverageObjectSize MetricIntf
+	MongodbCollectionDataSize          MetricIntf
+	MongodbCollectionIndexSize         MetricIntf
+	MongodbCollectionObjects           MetricIntf
+	MongodbCollectionOperationCount    MetricIntf
+	MongodbCollectionOperationTime     MetricIntf
+	MongodbCollectionStorageSize       MetricIntf
+	MongodbCollections                 MetricIntf
+	MongodbConnections                 MetricIntf
+	MongodbCurrentOperations           MetricIntf
+	MongodbCursorTimeouts              MetricIntf
+	MongodbCursors                     MetricIntf
+	MongodbDataSize                    MetricIntf
+	MongodbDocumentOperations          MetricIntf
+	MongodbExtents                     MetricIntf
+	MongodbGlobalLockActiveClients     MetricIntf
+	MongodbGlobalLockHoldTime          MetricIntf
+	MongodbGlobalLockQueue             MetricIntf
+	MongodbIndexAccesses               MetricIntf
+	MongodbIndexSize                   MetricIntf
+	MongodbIndexes                     MetricIntf
+	MongodbLockWaitingOperations       MetricIntf
+	MongodbLongestOperationAge         MetricIntf
+	MongodbMemberHealth                MetricIntf
+	MongodbMemberState                 MetricIntf
+	MongodbMemoryUsage                 MetricIntf
+	MongodbNetworkIo                   MetricIntf
+	MongodbNetworkRequests             MetricIntf
+	MongodbObjects                     MetricIntf
+	MongodbOperationLatencyCount       MetricIntf
+	MongodbOperationLatencyTime        MetricIntf
+	MongodbOperations                  MetricIntf
+	MongodbOplogWindow                 MetricIntf
+	MongodbReplicationLag              MetricIntf
+	MongodbStorageSize                 MetricIntf
+	MongodbTickets                     MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"mongodb.asserts",
+		"mongodb.balancer_enabled",
+		"mongodb.balancer_running",
+		"mongodb.cache_evictions",
 		"mongodb.cache_hits",
+		"mongodb.cache_limit",
 		"mongodb.cache_misses",
+		"mongodb.cache_usage",
+		"mongodb.chunks",
+		"mongodb.collection_average_object_size",
+		"mongodb.collection_data_size",
+		"mongodb.collection_index_size",
+		"mongodb.collection_objects",
+		"mongodb.collection_operation_count",
+		"mongodb.collection_operation_time",
+		"mongodb.collection_storage_size",
 		"mongodb.collections",
 		"mongodb.connections",
+		"mongodb.current_operations",
+		"mongodb.cursor_timeouts",
+		"mongodb.cursors",
 		"mongodb.data_size",
+		"mongodb.document_operations",
 		"mongodb.extents",
+		"mongodb.global_lock_active_clients",
 		"mongodb.global_lock_hold_time",
+		"mongodb.global_lock_queue",
+		"mongodb.index_accesses",
 		"mongodb.index_size",
 		"mongodb.indexes",
+		"mongodb.lock_waiting_operations",
+		"mongodb.longest_operation_age",
+		"mongodb.member_health",
+		"mongodb.member_state",
 		"mongodb.memory_usage",
+		"mongodb.network_io",
+		"mongodb.network_requests",
 		"mongodb.objects",
+		"mongodb.operation_latency_count",
+		"mongodb.operation_latency_time",
 		"mongodb.operations",
+		"mongodb.oplog_window",
+		"mongodb.replication_lag",
 		"mongodb.storage_size",
+		"mongodb.tickets",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"mongodb.cache_hits":            Metrics.MongodbCacheHits,
-	"mongodb.cache_misses":          Metrics.MongodbCacheMisses,
-	"mongodb.collections":           Metrics.MongodbCollections,
-	"mongodb.connections":           Metrics.MongodbConnections,
-	"mongodb.data_size":             Metrics.MongodbDataSize,
-	"mongodb.extents":               Metrics.MongodbExtents,
-	"mongodb.global_lock_hold_time": Metrics.MongodbGlobalLockHoldTime,
-	"mongodb.index_size":            Metrics.MongodbIndexSize,
-	"mongodb.indexes":               Metrics.MongodbIndexes,
-	"mongodb.memory_usage":          Metrics.MongodbMemoryUsage,
-	"mongodb.objects":               Metrics.MongodbObjects,
-	"mongodb.operations":            Metrics.MongodbOperations,
-	"mongodb.storage_size":          Metrics.MongodbStorageSize,
+	"mongodb.asserts":                        Metrics.MongodbAsserts,
+	"mongodb.balancer_enabled":               Metrics.MongodbBalancerEnabled,
+	"mongodb.balancer_running":               Metrics.MongodbBalancerRunning,
+	"mongodb.cache_evictions":                Metrics.MongodbCacheEvictions,
+	"mongodb.cache_hits":                     Metrics.MongodbCacheHits,
+	"mongodb.cache_limit":                    Metrics.MongodbCacheLimit,
+	"mongodb.cache_misses":                   Metrics.MongodbCacheMisses,
+	"mongodb.cache_usage":                    Metrics.MongodbCacheUsage,
+	"mongodb.chunks":                         Metrics.MongodbChunks,
+	"mongodb.collection_average_object_size": Metrics.MongodbCollectionAverageObjectSize,
+	"mongodb.collection_data_size":           Metrics.MongodbCollectionDataSize,
+	"mongodb.collection_index_size":          Metrics.MongodbCollectionIndexSize,
+	"mongodb.collection_objects":             Metrics.MongodbCollectionObjects,
+	"mongodb.collection_operation_count":     Metrics.MongodbCollectionOperationCount,
+	"mongodb.collection_operation_time":      Metrics.MongodbCollectionOperationTime,
+	"mongodb.collection_storage_size":        Metrics.MongodbCollectionStorageSize,
+	"mongodb.collections":                    Metrics.MongodbCollections,
+	"mongodb.connections":                    Metrics.MongodbConnections,
+	"mongodb.current_operations":             Metrics.MongodbCurrentOperations,
+	"mongodb.cursor_timeouts":                Metrics.MongodbCursorTimeouts,
+	"mongodb.cursors":                        Metrics.MongodbCursors,
+	"mongodb.data_size":                      Metrics.MongodbDataSize,
+	"mongodb.document_operations":            Metrics.MongodbDocumentOperations,
+	"mongodb.extents":                        Metrics.MongodbExtents,
+	"mongodb.global_lock_active_clients":     Metrics.MongodbGlobalLockActiveClients,
+	"mongodb.global_lock_hold_time":          Metrics.MongodbGlobalLockHoldTime,
+	"mongodb.global_lock_queue":              Metrics.MongodbGlobalLockQueue,
+	"mongodb.index_accesses":                 Metrics.MongodbIndexAccesses,
+	"mongodb.index_size":                     Metrics.MongodbIndexSize,
+	"mongodb.indexes":                        Metrics.MongodbIndexes,
+	"mongodb.lock_waiting_operations":        Metrics.MongodbLockWaitingOperations,
+	"mongodb.longest_operation_age":          Metrics.MongodbLongestOperationAge,
+	"mongodb.member_health":                  Metrics.MongodbMemberHealth,
+	"mongodb.member_state":                   Metrics.MongodbMemberState,
+	"mongodb.memory_usage":                   Metrics.MongodbMemoryUsage,
+	"mongodb.network_io":                     Metrics.MongodbNetworkIo,
+	"mongodb.network_requests":               Metrics.MongodbNetworkRequests,
+	"mongodb.objects":                        Metrics.MongodbObjects,
+	"mongodb.operation_latency_count":        Metrics.MongodbOperationLatencyCount,
+	"mongodb.operation_latency_time":         Metrics.MongodbOperationLatencyTime,
+	"mongodb.operations":                     Metrics.MongodbOperations,
+	"mongodb.oplog_window":                   Metrics.MongodbOplogWindow,
+	"mongodb.replication_lag":                Metrics.MongodbReplicationLag,
+	"mongodb.storage_size":                   Metrics.MongodbStorageSize,
+	"mongodb.tickets":                        Metrics.MongodbTickets,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -98,6 +194,46 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"mongodb.asserts",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.asserts")
+			metric.SetDescription("The number of assertions raised since the server started.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mongodb.balancer_enabled",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.balancer_enabled")
+			metric.SetDescription("Whether the balancer is enabled, 1 if it is and 0 if it is not.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.balancer_running",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.balancer_running")
+			metric.SetDescription("Whether a balancing round is in progress, 1 if one is and 0 if not.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.cache_evictions",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.cache_evictions")
+			metric.SetDescription("The number of pages evicted from the WiredTiger cache.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"mongodb.cache_hits",
 		func(metric pdata.Metric) {
@@ -109,6 +245,15 @@ var Metrics = &metricStruct{
 			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"mongodb.cache_limit",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.cache_limit")
+			metric.SetDescription("The maximum size of the WiredTiger cache.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"mongodb.cache_misses",
 		func(metric pdata.Metric) {
@@ -120,6 +265,91 @@ var Metrics = &metricStruct{
 			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"mongodb.cache_usage",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.cache_usage")
+			metric.SetDescription("The amount of data in the WiredTiger cache.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.chunks",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.chunks")
+			metric.SetDescription("The number of chunks of a sharded collection held by a shard.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_average_object_size",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_average_object_size")
+			metric.SetDescription("The average size of a document in a collection.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_data_size",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_data_size")
+			metric.SetDescription("The uncompressed size of the documents in a collection.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_index_size",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_index_size")
+			metric.SetDescription("The total size of the indexes of a collection.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_objects",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_objects")
+			metric.SetDescription("The number of documents in a collection.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_operation_count",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_operation_count")
+			metric.SetDescription("The number of operations on a collection since the server started, as reported by top.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_operation_time",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_operation_time")
+			metric.SetDescription("The total time spent on operations on a collection since the server started, as reported by top.")
+			metric.SetUnit("us")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mongodb.collection_storage_size",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.collection_storage_size")
+			metric.SetDescription("The storage allocated to a collection.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"mongodb.collections",
 		func(metric pdata.Metric) {
@@ -138,6 +368,35 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.current_operations",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.current_operations")
+			metric.SetDescription("The number of operations in progress.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.cursor_timeouts",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.cursor_timeouts")
+			metric.SetDescription("The number of cursors that timed out since the server started.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mongodb.cursors",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.cursors")
+			metric.SetDescription("The number of cursors open on the server.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"mongodb.data_size",
 		func(metric pdata.Metric) {
@@ -147,6 +406,17 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.document_operations",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.document_operations")
+			metric.SetDescription("The number of documents returned, inserted, updated or deleted.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"mongodb.extents",
 		func(metric pdata.Metric) {
@@ -156,6 +426,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.global_lock_active_clients",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.global_lock_active_clients")
+			metric.SetDescription("The number of connected clients performing read or write operations.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"mongodb.global_lock_hold_time",
 		func(metric pdata.Metric) {
@@ -167,6 +446,26 @@ var Metrics = &metricStruct{
 			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"mongodb.global_lock_queue",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.global_lock_queue")
+			metric.SetDescription("The number of operations queued waiting for a lock.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.index_accesses",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.index_accesses")
+			metric.SetDescription("The number of operations that used an index since the server started or the index was created.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"mongodb.index_size",
 		func(metric pdata.Metric) {
@@ -185,6 +484,42 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.lock_waiting_operations",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.lock_waiting_operations")
+			metric.SetDescription("The number of operations in progress that are waiting for a lock.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.longest_operation_age",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.longest_operation_age")
+			metric.SetDescription("The time the longest running operation in progress has been running.")
+			metric.SetUnit("ms")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.member_health",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.member_health")
+			metric.SetDescription("The health of a replica set member, 1 if it is up and 0 if it is down.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.member_state",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.member_state")
+			metric.SetDescription("The replica set state of a member, such as 1 for PRIMARY and 2 for SECONDARY.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"mongodb.memory_usage",
 		func(metric pdata.Metric) {
@@ -194,6 +529,28 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.network_io",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.network_io")
+			metric.SetDescription("The number of bytes received from or sent to the network since the server started.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mongodb.network_requests",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.network_requests")
+			metric.SetDescription("The number of requests received by the server since it started.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"mongodb.objects",
 		func(metric pdata.Metric) {
@@ -203,6 +560,28 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.operation_latency_count",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.operation_latency_count")
+			metric.SetDescription("The number of operations included in the total operation latency.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"mongodb.operation_latency_time",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.operation_latency_time")
+			metric.SetDescription("The total latency of operations.")
+			metric.SetUnit("us")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 	&metricImpl{
 		"mongodb.operations",
 		func(metric pdata.Metric) {
@@ -214,6 +593,24 @@ var Metrics = &metricStruct{
 			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
 		},
 	},
+	&metricImpl{
+		"mongodb.oplog_window",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.oplog_window")
+			metric.SetDescription("The time between the oldest and newest entries in the oplog.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"mongodb.replication_lag",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.replication_lag")
+			metric.SetDescription("The time a secondary's last applied operation trails the primary's.")
+			metric.SetUnit("s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"mongodb.storage_size",
 		func(metric pdata.Metric) {
@@ -223,6 +620,15 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"mongodb.tickets",
+		func(metric pdata.Metric) {
+			metric.SetName("mongodb.tickets")
+			metric.SetDescription("The number of WiredTiger concurrent transaction tickets.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -231,24 +637,91 @@ var M = Metrics
 
 // Attributes contains the possible metric attributes that can be used.
 var Attributes = struct {
+	// AssertType (The type of assertion raised.)
+	AssertType string
+	// CacheStatus (The status of the data in the cache.)
+	CacheStatus string
+	// CollectionName (The name of a collection.)
+	CollectionName string
 	// ConnectionType (The status of the connection.)
 	ConnectionType string
+	// CursorType (The type of open cursor.)
+	CursorType string
 	// DatabaseName (The name of a database.)
 	DatabaseName string
+	// Direction (The direction of network traffic.)
+	Direction string
+	// DocumentOperation (The operation performed on documents.)
+	DocumentOperation string
+	// EvictionType (The type of page evicted from the cache.)
+	EvictionType string
+	// IndexName (The name of an index.)
+	IndexName string
+	// LatencyOperation (The type of operation whose latency is measured.)
+	LatencyOperation string
+	// LockClientType (The type of client of the global lock.)
+	LockClientType string
+	// MemberHost (The host and port of a replica set member.)
+	MemberHost string
 	// MemoryType (The type of memory used.)
 	MemoryType string
 	// Operation (The mongoDB operation being counted.)
 	Operation string
+	// ReplicaSet (The name of a replica set.)
+	ReplicaSet string
+	// ShardName (The name of a shard.)
+	ShardName string
+	// TicketState (The state of concurrent transaction tickets.)
+	TicketState string
+	// TicketType (The type of concurrent transaction ticket.)
+	TicketType string
 }{
+	"assert_type",
+	"cache_status",
+	"collection_name",
 	"connection_type",
+	"cursor_type",
 	"database_name",
+	"direction",
+	"document_operation",
+	"eviction_type",
+	"index_name",
+	"latency_operation",
+	"lock_client_type",
+	"member_host",
 	"memory_type",
 	"operation",
+	"replica_set",
+	"shard_name",
+	"ticket_state",
+	"ticket_type",
 }
 
 // A is an alias for Attributes.
 var A = Attributes
 
+// AttributeAssertType are the possible values that the attribute "assert_type" can have.
+var AttributeAssertType = struct {
+	Regular string
+	Warning string
+	Msg     string
+	User    string
+}{
+	"regular",
+	"warning",
+	"msg",
+	"user",
+}
+
+// AttributeCacheStatus are the possible values that the attribute "cache_status" can have.
+var AttributeCacheStatus = struct {
+	Used  string
+	Dirty string
+}{
+	"used",
+	"dirty",
+}
+
 // AttributeConnectionType are the possible values that the attribute "connection_type" can have.
 var AttributeConnectionType = struct {
 	Active    string
@@ -260,6 +733,68 @@ var AttributeConnectionType = struct {
 	"current",
 }
 
+// AttributeCursorType are the possible values that the attribute "cursor_type" can have.
+var AttributeCursorType = struct {
+	Total     string
+	Pinned    string
+	NoTimeout string
+}{
+	"total",
+	"pinned",
+	"noTimeout",
+}
+
+// AttributeDirection are the possible values that the attribute "direction" can have.
+var AttributeDirection = struct {
+	Received    string
+	Transmitted string
+}{
+	"received",
+	"transmitted",
+}
+
+// AttributeDocumentOperation are the possible values that the attribute "document_operation" can have.
+var AttributeDocumentOperation = struct {
+	Returned string
+	Inserted string
+	Updated  string
+	Deleted  string
+}{
+	"returned",
+	"inserted",
+	"updated",
+	"deleted",
+}
+
+// AttributeEvictionType are the possible values that the attribute "eviction_type" can have.
+var AttributeEvictionType = struct {
+	Modified   string
+	Unmodified string
+}{
+	"modified",
+	"unmodified",
+}
+
+// AttributeLatencyOperation are the possible values that the attribute "latency_operation" can have.
+var AttributeLatencyOperation = struct {
+	Reads    string
+	Writes   string
+	Commands string
+}{
+	"reads",
+	"writes",
+	"commands",
+}
+
+// AttributeLockClientType are the possible values that the attribute "lock_client_type" can have.
+var AttributeLockClientType = struct {
+	Readers string
+	Writers string
+}{
+	"readers",
+	"writers",
+}
+
 // AttributeMemoryType are the possible values that the attribute "memory_type" can have.
 var AttributeMemoryType = struct {
 	Resident          string
@@ -289,3 +824,21 @@ var AttributeOperation = struct {
 	"getmore",
 	"command",
 }
+
+// AttributeTicketState are the possible values that the attribute "ticket_state" can have.
+var AttributeTicketState = struct {
+	Available string
+	Out       string
+}{
+	"available",
+	"out",
+}
+
+// AttributeTicketType are the possible values that the attribute "ticket_type" can have.
+var AttributeTicketType = struct {
+	Read  string
+	Write string
+}{
+	"read",
+	"write",
+}
@@ -0,0 +1,143 @@
+package mongodbreceiver
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/observiq/opentelemetry-components/receiver/mongodbreceiver/internal/metadata"
+)
+
+// oplogNamespace is the namespace of the oplog that secondaries replicate from.
+const oplogNamespace = "local.oplog.rs"
+
+// currentOpPipeline lists the active operations of all users, leaving out the aggregation running it.
+var currentOpPipeline = mongo.Pipeline{
+	{{Key: "$currentOp", Value: bson.D{{Key: "allUsers", Value: true}}}},
+	{{Key: "$match", Value: bson.D{
+		{Key: "active", Value: true},
+		{Key: "command.pipeline.0.$currentOp", Value: bson.D{{Key: "$exists", Value: false}}},
+	}}},
+}
+
+// currentOpTypes maps the op field of $currentOp to the operation attribute. Other operation
+// types, such as internal threads reported as "none", are not counted.
+var currentOpTypes = map[string]string{
+	"insert":  metadata.AttributeOperation.Insert,
+	"query":   metadata.AttributeOperation.Query,
+	"update":  metadata.AttributeOperation.Update,
+	"remove":  metadata.AttributeOperation.Delete,
+	"getmore": metadata.AttributeOperation.Getmore,
+	"command": metadata.AttributeOperation.Command,
+}
+
+// topOperations maps the per-operation fields of the top command to the operation attribute.
+var topOperations = map[string]string{
+	"insert":   metadata.AttributeOperation.Insert,
+	"queries":  metadata.AttributeOperation.Query,
+	"update":   metadata.AttributeOperation.Update,
+	"remove":   metadata.AttributeOperation.Delete,
+	"getmore":  metadata.AttributeOperation.Getmore,
+	"commands": metadata.AttributeOperation.Command,
+}
+
+type operationKey struct {
+	operation  string
+	database   string
+	collection string
+}
+
+// collectCurrentOpMetrics reports the number of operations in progress per operation type and namespace,
+// the age of the longest running one and how many are waiting for a lock.
+func (r *mongodbScraper) collectCurrentOpMetrics(ctx context.Context, client client, mm *metricManager) {
+	operations, err := client.aggregate(ctx, "admin", "", currentOpPipeline)
+	if err != nil {
+		r.logger.Error("Failed to collect $currentOp metrics", zap.Error(err))
+		return
+	}
+
+	counts := map[operationKey]int64{}
+	var longest, waiting int64
+	for _, op := range operations {
+		if waitingForLock, _ := op["waitingForLock"].(bool); waitingForLock {
+			waiting++
+		}
+
+		opType, _ := op["op"].(string)
+		operation, ok := currentOpTypes[opType]
+		if !ok {
+			continue
+		}
+		namespace, _ := op["ns"].(string)
+		database, collection := splitNamespace(namespace)
+		counts[operationKey{operation: operation, database: database, collection: collection}]++
+
+		// secondaries tail the oplog of their sync source with getMores that never finish, so they
+		// would always be the longest running operation
+		if operation == metadata.AttributeOperation.Getmore && namespace == oplogNamespace {
+			continue
+		}
+		if running, err := parseInt(op["microsecs_running"]); err == nil && running > longest {
+			longest = running
+		}
+	}
+
+	for key, count := range counts {
+		attributes := pdata.NewAttributeMap()
+		attributes.Insert(metadata.A.DatabaseName, pdata.NewAttributeValueString(key.database))
+		attributes.Insert(metadata.A.CollectionName, pdata.NewAttributeValueString(key.collection))
+		attributes.Insert(metadata.A.Operation, pdata.NewAttributeValueString(key.operation))
+		mm.addIntDataPoint(metadata.M.MongodbCurrentOperations, count, attributes)
+	}
+	mm.addIntDataPoint(metadata.M.MongodbLockWaitingOperations, waiting, pdata.NewAttributeMap())
+	mm.addIntDataPoint(metadata.M.MongodbLongestOperationAge, longest/1000, pdata.NewAttributeMap())
+}
+
+// collectTopMetrics reports the time spent on and number of operations per collection from the top command.
+// Only the databases selected by the databases config are reported.
+func (r *mongodbScraper) collectTopMetrics(ctx context.Context, client client, mm *metricManager) {
+	top, err := client.query(ctx, "admin", bson.D{{Key: "top", Value: 1}})
+	if err != nil {
+		r.logger.Error("Failed to query top in admin", zap.Error(err))
+		return
+	}
+
+	totals, ok := top["totals"].(bson.M)
+	if !ok {
+		r.logger.Error("Failed to find totals in top")
+		return
+	}
+
+	for namespace, value := range totals {
+		// totals also holds a note next to the namespaces
+		usage, ok := value.(bson.M)
+		if !ok {
+			continue
+		}
+		database, collection := splitNamespace(namespace)
+		if !r.config.Databases.matches(database) {
+			continue
+		}
+
+		for field, operation := range topOperations {
+			attributes := pdata.NewAttributeMap()
+			attributes.Insert(metadata.A.DatabaseName, pdata.NewAttributeValueString(database))
+			attributes.Insert(metadata.A.CollectionName, pdata.NewAttributeValueString(collection))
+			attributes.Insert(metadata.A.Operation, pdata.NewAttributeValueString(operation))
+
+			if opTime, err := getIntMetricValue(usage, []string{field, "time"}); err == nil {
+				mm.addIntDataPoint(metadata.M.MongodbCollectionOperationTime, opTime, attributes)
+			} else {
+				r.logger.Error("Failed to Parse", zap.Error(err), zap.String("metric", metadata.M.MongodbCollectionOperationTime.Name()))
+			}
+			if count, err := getIntMetricValue(usage, []string{field, "count"}); err == nil {
+				mm.addIntDataPoint(metadata.M.MongodbCollectionOperationCount, count, attributes)
+			} else {
+				r.logger.Error("Failed to Parse", zap.Error(err), zap.String("metric", metadata.M.MongodbCollectionOperationCount.Name()))
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package mongodbreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/observiq/opentelemetry-components/receiver/mongodbreceiver/internal/metadata"
+)
+
+const (
+	// noReplicationEnabledCode is returned by replSetGetStatus when the server is not a replica set member.
+	noReplicationEnabledCode = 76
+
+	memberStatePrimary   = 1
+	memberStateSecondary = 2
+)
+
+// collectReplicationMetrics reports the state of each replica set member and the oplog window of the
+// connected server. Nothing is reported when the server is not part of a replica set. When hosts are
+// scraped separately, each reports only its own state, so that members are not reported once per host.
+func (r *mongodbScraper) collectReplicationMetrics(ctx context.Context, client client, mm *metricManager) {
+	status, err := client.query(ctx, "admin", bson.D{{Key: "replSetGetStatus", Value: 1}})
+	if err != nil {
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == noReplicationEnabledCode {
+			r.logger.Debug("Server is not a replica set member, skipping replication metrics")
+			return
+		}
+		r.logger.Error("Failed to query replSetGetStatus in admin", zap.Error(err))
+		return
+	}
+
+	replicaSet, _ := status["set"].(string)
+	r.parseReplicaSetMembers(mm, replicaSet, status, len(r.config.Hosts) > 0)
+
+	window, err := oplogWindow(ctx, client)
+	if err != nil {
+		r.logger.Error("Failed to calculate oplog window", zap.Error(err))
+		return
+	}
+	attributes := pdata.NewAttributeMap()
+	attributes.Insert(metadata.A.ReplicaSet, pdata.NewAttributeValueString(replicaSet))
+	mm.addIntDataPoint(metadata.M.MongodbOplogWindow, window, attributes)
+}
+
+func (r *mongodbScraper) parseReplicaSetMembers(mm *metricManager, replicaSet string, status bson.M, selfOnly bool) {
+	members, ok := status["members"].(bson.A)
+	if !ok {
+		r.logger.Error("Failed to find members in replSetGetStatus")
+		return
+	}
+
+	var primaryOptime primitive.DateTime
+	hasPrimary := false
+	for _, m := range members {
+		member, ok := m.(bson.M)
+		if !ok {
+			continue
+		}
+		if state, err := parseInt(member["state"]); err == nil && state == memberStatePrimary {
+			primaryOptime, hasPrimary = member["optimeDate"].(primitive.DateTime)
+		}
+	}
+
+	for _, m := range members {
+		member, ok := m.(bson.M)
+		if !ok {
+			continue
+		}
+		if self, _ := member["self"].(bool); selfOnly && !self {
+			continue
+		}
+		host, _ := member["name"].(string)
+		attributes := pdata.NewAttributeMap()
+		attributes.Insert(metadata.A.ReplicaSet, pdata.NewAttributeValueString(replicaSet))
+		attributes.Insert(metadata.A.MemberHost, pdata.NewAttributeValueString(host))
+
+		state, err := parseInt(member["state"])
+		if err != nil {
+			r.logger.Error("Failed to Parse", zap.Error(err), zap.String("metric", metadata.M.MongodbMemberState.Name()))
+			continue
+		}
+		mm.addIntDataPoint(metadata.M.MongodbMemberState, state, attributes)
+
+		// health is reported as a double even though it can only be 0 or 1
+		health, err := parseDouble(member["health"])
+		if err != nil {
+			r.logger.Error("Failed to Parse", zap.Error(err), zap.String("metric", metadata.M.MongodbMemberHealth.Name()))
+		} else {
+			mm.addIntDataPoint(metadata.M.MongodbMemberHealth, int64(health), attributes)
+		}
+
+		if state != memberStateSecondary || !hasPrimary {
+			continue
+		}
+		optime, ok := member["optimeDate"].(primitive.DateTime)
+		if !ok {
+			r.logger.Error("Failed to find optimeDate", zap.String("member", host))
+			continue
+		}
+		lag := primaryOptime.Time().Sub(optime.Time())
+		mm.addIntDataPoint(metadata.M.MongodbReplicationLag, int64(lag.Seconds()), attributes)
+	}
+}
+
+// oplogWindow returns the number of seconds between the oldest and newest entries in local.oplog.rs.
+func oplogWindow(ctx context.Context, client client) (int64, error) {
+	first, err := oplogTimestamp(ctx, client, 1)
+	if err != nil {
+		return 0, err
+	}
+	last, err := oplogTimestamp(ctx, client, -1)
+	if err != nil {
+		return 0, err
+	}
+	return int64(last.T) - int64(first.T), nil
+}
+
+// oplogTimestamp returns the timestamp of the first oplog entry in natural order, or the last if direction is -1.
+func oplogTimestamp(ctx context.Context, client client, direction int) (primitive.Timestamp, error) {
+	result, err := client.query(ctx, "local", bson.D{
+		{Key: "find", Value: "oplog.rs"},
+		{Key: "sort", Value: bson.D{{Key: "$natural", Value: direction}}},
+		{Key: "limit", Value: 1},
+		{Key: "projection", Value: bson.D{{Key: "ts", Value: 1}}},
+	})
+	if err != nil {
+		return primitive.Timestamp{}, err
+	}
+
+	batch, err := digValue(result, []string{"cursor", "firstBatch"})
+	if err != nil {
+		return primitive.Timestamp{}, err
+	}
+	entries, ok := batch.(bson.A)
+	if !ok || len(entries) == 0 {
+		return primitive.Timestamp{}, errors.New("oplog is empty")
+	}
+	entry, ok := entries[0].(bson.M)
+	if !ok {
+		return primitive.Timestamp{}, fmt.Errorf("unexpected oplog entry: %v", entries[0])
+	}
+	ts, ok := entry["ts"].(primitive.Timestamp)
+	if !ok {
+		return primitive.Timestamp{}, fmt.Errorf("unexpected oplog timestamp: %v", entry["ts"])
+	}
+	return ts, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"reflect"
 	"strconv"
 	"time"
@@ -13,11 +14,32 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 
 	"github.com/observiq/opentelemetry-components/receiver/mongodbreceiver/internal/metadata"
 )
 
+// Resource attributes identifying the server that metrics were scraped from.
+const (
+	hostResourceAttribute       = "mongodb.host"
+	portResourceAttribute       = "mongodb.port"
+	versionResourceAttribute    = "mongodb.version"
+	replicaSetResourceAttribute = "mongodb.replica_set"
+	roleResourceAttribute       = "mongodb.role"
+)
+
+// Values of the mongodb.role resource attribute.
+const (
+	rolePrimary    = "primary"
+	roleSecondary  = "secondary"
+	roleArbiter    = "arbiter"
+	roleStandalone = "standalone"
+	roleOther      = "other"
+)
+
+const defaultPort = 27017
+
 type mongodbScraper struct {
 	logger      *zap.Logger
 	config      *Config
@@ -119,6 +141,228 @@ var serverStatusMetrics = []mongoMetric{
 		staticAttributes: map[string]string{metadata.A.MemoryType: metadata.AttributeMemoryType.MappedWithJournal},
 		dataPointType:    integer,
 	},
+	{
+		metricDef:        metadata.M.MongodbCursors,
+		path:             []string{"metrics", "cursor", "open", "total"},
+		staticAttributes: map[string]string{metadata.A.CursorType: metadata.AttributeCursorType.Total},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbCursors,
+		path:             []string{"metrics", "cursor", "open", "pinned"},
+		staticAttributes: map[string]string{metadata.A.CursorType: metadata.AttributeCursorType.Pinned},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbCursors,
+		path:             []string{"metrics", "cursor", "open", "noTimeout"},
+		staticAttributes: map[string]string{metadata.A.CursorType: metadata.AttributeCursorType.NoTimeout},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbCursorTimeouts,
+		path:          []string{"metrics", "cursor", "timedOut"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbAsserts,
+		path:             []string{"asserts", "regular"},
+		staticAttributes: map[string]string{metadata.A.AssertType: metadata.AttributeAssertType.Regular},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbAsserts,
+		path:             []string{"asserts", "warning"},
+		staticAttributes: map[string]string{metadata.A.AssertType: metadata.AttributeAssertType.Warning},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbAsserts,
+		path:             []string{"asserts", "msg"},
+		staticAttributes: map[string]string{metadata.A.AssertType: metadata.AttributeAssertType.Msg},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbAsserts,
+		path:             []string{"asserts", "user"},
+		staticAttributes: map[string]string{metadata.A.AssertType: metadata.AttributeAssertType.User},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbNetworkIo,
+		path:             []string{"network", "bytesIn"},
+		staticAttributes: map[string]string{metadata.A.Direction: metadata.AttributeDirection.Received},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbNetworkIo,
+		path:             []string{"network", "bytesOut"},
+		staticAttributes: map[string]string{metadata.A.Direction: metadata.AttributeDirection.Transmitted},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbNetworkRequests,
+		path:          []string{"network", "numRequests"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbGlobalLockQueue,
+		path:             []string{"globalLock", "currentQueue", "readers"},
+		staticAttributes: map[string]string{metadata.A.LockClientType: metadata.AttributeLockClientType.Readers},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbGlobalLockQueue,
+		path:             []string{"globalLock", "currentQueue", "writers"},
+		staticAttributes: map[string]string{metadata.A.LockClientType: metadata.AttributeLockClientType.Writers},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbGlobalLockActiveClients,
+		path:             []string{"globalLock", "activeClients", "readers"},
+		staticAttributes: map[string]string{metadata.A.LockClientType: metadata.AttributeLockClientType.Readers},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbGlobalLockActiveClients,
+		path:             []string{"globalLock", "activeClients", "writers"},
+		staticAttributes: map[string]string{metadata.A.LockClientType: metadata.AttributeLockClientType.Writers},
+		dataPointType:    integer,
+	},
+}
+
+// wiredTigerMetrics are read from the wiredTiger section of serverStatus, which is only present
+// when the server uses the WiredTiger storage engine.
+var wiredTigerMetrics = []mongoMetric{
+	{
+		metricDef:        metadata.M.MongodbCacheUsage,
+		path:             []string{"wiredTiger", "cache", "bytes currently in the cache"},
+		staticAttributes: map[string]string{metadata.A.CacheStatus: metadata.AttributeCacheStatus.Used},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbCacheUsage,
+		path:             []string{"wiredTiger", "cache", "tracked dirty bytes in the cache"},
+		staticAttributes: map[string]string{metadata.A.CacheStatus: metadata.AttributeCacheStatus.Dirty},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:     metadata.M.MongodbCacheLimit,
+		path:          []string{"wiredTiger", "cache", "maximum bytes configured"},
+		dataPointType: integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbCacheEvictions,
+		path:             []string{"wiredTiger", "cache", "modified pages evicted"},
+		staticAttributes: map[string]string{metadata.A.EvictionType: metadata.AttributeEvictionType.Modified},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbCacheEvictions,
+		path:             []string{"wiredTiger", "cache", "unmodified pages evicted"},
+		staticAttributes: map[string]string{metadata.A.EvictionType: metadata.AttributeEvictionType.Unmodified},
+		dataPointType:    integer,
+	},
+	{
+		metricDef: metadata.M.MongodbTickets,
+		path:      []string{"wiredTiger", "concurrentTransactions", "read", "available"},
+		staticAttributes: map[string]string{
+			metadata.A.TicketType:  metadata.AttributeTicketType.Read,
+			metadata.A.TicketState: metadata.AttributeTicketState.Available,
+		},
+		dataPointType: integer,
+	},
+	{
+		metricDef: metadata.M.MongodbTickets,
+		path:      []string{"wiredTiger", "concurrentTransactions", "read", "out"},
+		staticAttributes: map[string]string{
+			metadata.A.TicketType:  metadata.AttributeTicketType.Read,
+			metadata.A.TicketState: metadata.AttributeTicketState.Out,
+		},
+		dataPointType: integer,
+	},
+	{
+		metricDef: metadata.M.MongodbTickets,
+		path:      []string{"wiredTiger", "concurrentTransactions", "write", "available"},
+		staticAttributes: map[string]string{
+			metadata.A.TicketType:  metadata.AttributeTicketType.Write,
+			metadata.A.TicketState: metadata.AttributeTicketState.Available,
+		},
+		dataPointType: integer,
+	},
+	{
+		metricDef: metadata.M.MongodbTickets,
+		path:      []string{"wiredTiger", "concurrentTransactions", "write", "out"},
+		staticAttributes: map[string]string{
+			metadata.A.TicketType:  metadata.AttributeTicketType.Write,
+			metadata.A.TicketState: metadata.AttributeTicketState.Out,
+		},
+		dataPointType: integer,
+	},
+}
+
+// operationMetrics are document and latency counters reported by serverStatus.
+var operationMetrics = []mongoMetric{
+	{
+		metricDef:        metadata.M.MongodbDocumentOperations,
+		path:             []string{"metrics", "document", "returned"},
+		staticAttributes: map[string]string{metadata.A.DocumentOperation: metadata.AttributeDocumentOperation.Returned},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbDocumentOperations,
+		path:             []string{"metrics", "document", "inserted"},
+		staticAttributes: map[string]string{metadata.A.DocumentOperation: metadata.AttributeDocumentOperation.Inserted},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbDocumentOperations,
+		path:             []string{"metrics", "document", "updated"},
+		staticAttributes: map[string]string{metadata.A.DocumentOperation: metadata.AttributeDocumentOperation.Updated},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbDocumentOperations,
+		path:             []string{"metrics", "document", "deleted"},
+		staticAttributes: map[string]string{metadata.A.DocumentOperation: metadata.AttributeDocumentOperation.Deleted},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbOperationLatencyTime,
+		path:             []string{"opLatencies", "reads", "latency"},
+		staticAttributes: map[string]string{metadata.A.LatencyOperation: metadata.AttributeLatencyOperation.Reads},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbOperationLatencyCount,
+		path:             []string{"opLatencies", "reads", "ops"},
+		staticAttributes: map[string]string{metadata.A.LatencyOperation: metadata.AttributeLatencyOperation.Reads},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbOperationLatencyTime,
+		path:             []string{"opLatencies", "writes", "latency"},
+		staticAttributes: map[string]string{metadata.A.LatencyOperation: metadata.AttributeLatencyOperation.Writes},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbOperationLatencyCount,
+		path:             []string{"opLatencies", "writes", "ops"},
+		staticAttributes: map[string]string{metadata.A.LatencyOperation: metadata.AttributeLatencyOperation.Writes},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbOperationLatencyTime,
+		path:             []string{"opLatencies", "commands", "latency"},
+		staticAttributes: map[string]string{metadata.A.LatencyOperation: metadata.AttributeLatencyOperation.Commands},
+		dataPointType:    integer,
+	},
+	{
+		metricDef:        metadata.M.MongodbOperationLatencyCount,
+		path:             []string{"opLatencies", "commands", "ops"},
+		staticAttributes: map[string]string{metadata.A.LatencyOperation: metadata.AttributeLatencyOperation.Commands},
+		dataPointType:    integer,
+	},
 }
 
 func newMongodbScraper(logger *zap.Logger, config *Config) *mongodbScraper {
@@ -137,78 +381,209 @@ func (r *mongodbScraper) start(ctx context.Context, host component.Host) error {
 }
 
 func (r *mongodbScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	if len(r.config.Hosts) == 0 {
+		return r.scrapeServer(ctx, r.config)
+	}
+
+	rms := pdata.NewMetrics()
+	var errs scrapererror.ScrapeErrors
+	for _, host := range r.config.Hosts {
+		hostMetrics, err := r.scrapeServer(ctx, r.config.forHost(host))
+		if err != nil {
+			r.logger.Error("Failed to scrape host", zap.Error(err), zap.String("host", host.Endpoint))
+			errs.AddPartial(len(metadata.M.Names()), err)
+			continue
+		}
+		hostMetrics.ResourceMetrics().MoveAndAppendTo(rms.ResourceMetrics())
+	}
+
+	// the hosts that could be scraped are still reported when others are down
+	return rms, errs.Combine()
+}
+
+// scrapeServer scrapes the server that config connects to, or the sharded cluster behind it if it is a mongos router.
+func (r *mongodbScraper) scrapeServer(ctx context.Context, config *Config) (pdata.Metrics, error) {
 	// Init client in scrape method to create a new connection for each scrape.
-	client, err := r.buildClient(r.config, r.logger)
+	client, err := r.connect(ctx, config)
 	if err != nil {
-		r.logger.Error("Failed to create client", zap.Error(err))
 		return pdata.NewMetrics(), err
 	}
+	defer r.disconnect(ctx, client)
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	isMaster, err := client.query(ctx, "admin", bson.D{{Key: "isMaster", Value: 1}})
+	if err != nil {
+		r.logger.Error("Failed to determine server type", zap.Error(err))
+	} else if isMongos(isMaster) {
+		return r.collectShardedMetrics(ctx, client)
+	}
+
+	return r.collectMetrics(ctx, client, isMaster)
+}
+
+// connect creates a client for the given config and checks that the server is reachable.
+func (r *mongodbScraper) connect(ctx context.Context, config *Config) (client, error) {
+	client, err := r.buildClient(config, r.logger)
+	if err != nil {
+		r.logger.Error("Failed to create client", zap.Error(err))
+		return nil, err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, config.Timeout)
 	defer cancel()
 
 	if err := client.Connect(timeoutCtx); err != nil {
 		r.logger.Error("Failed to connect to client", zap.Error(err))
-		return pdata.NewMetrics(), err
+		return nil, err
 	}
 
-	defer func() {
-		if err := client.Disconnect(ctx); err != nil {
-			r.logger.Error("Failed to disconnect from client", zap.Error(err))
-		}
-	}()
-
-	ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+	pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
-	err = client.Ping(ctx, readpref.PrimaryPreferred())
+	err = client.Ping(pingCtx, readpref.PrimaryPreferred())
 	if err != nil {
 		r.logger.Error("Failed to ping server", zap.Error(err))
-		return pdata.NewMetrics(), err
+		r.disconnect(ctx, client)
+		return nil, err
 	}
 
-	return r.collectMetrics(ctx, client)
+	return client, nil
 }
 
-func (r *mongodbScraper) collectMetrics(ctx context.Context, client client) (pdata.Metrics, error) {
+func (r *mongodbScraper) disconnect(ctx context.Context, client client) {
+	if err := client.Disconnect(ctx); err != nil {
+		r.logger.Error("Failed to disconnect from client", zap.Error(err))
+	}
+}
+
+// collectMetrics reports the metrics of a mongod server. isMaster is its response to the isMaster command,
+// which is used to identify the server, and may be nil if it could not be queried.
+func (r *mongodbScraper) collectMetrics(ctx context.Context, client client, isMaster bson.M) (pdata.Metrics, error) {
 	rms := pdata.NewMetrics()
-	ilm := rms.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
-	ilm.InstrumentationLibrary().SetName("otelcol/mongodb")
-	mm := newMetricManager(r.logger, ilm)
+	rm := rms.ResourceMetrics().AppendEmpty()
+	mm := newMetricManager(r.logger, newInstrumentationLibraryMetrics(rm))
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, r.config.Timeout)
-	defer cancel()
-	dbNames, err := client.ListDatabaseNames(timeoutCtx, bson.D{})
+	dbNames, err := r.databaseNames(ctx, client)
 	if err != nil {
 		r.logger.Error("Failed to fetch database names", zap.Error(err))
 		return pdata.Metrics{}, err
 	}
 
-	serverStatus, err := client.query(ctx, "admin", bson.M{"serverStatus": 1})
+	serverStatus, err := client.query(ctx, "admin", bson.D{{Key: "serverStatus", Value: 1}})
 	if err != nil {
 		r.logger.Error("Failed to query serverStatus in admin", zap.Error(err))
 	} else {
-		r.parseSpecialMetrics(ctx, mm, serverStatus)
+		r.parseServerMetrics(ctx, mm, serverStatus)
+	}
+	setServerResourceAttributes(rm.Resource(), isMaster, serverStatus)
+
+	r.collectReplicationMetrics(ctx, client, mm)
+
+	if r.config.Operations.CurrentOp {
+		r.collectCurrentOpMetrics(ctx, client, mm)
+	}
+	if r.config.Operations.Top {
+		r.collectTopMetrics(ctx, client, mm)
 	}
 
 	for _, dbName := range dbNames {
-		dbStats, err := client.query(ctx, dbName, bson.M{"dbStats": 1})
+		dbStats, err := client.query(ctx, dbName, bson.D{{Key: "dbStats", Value: 1}})
 		if err != nil {
 			r.logger.Error("Failed to collect dbStats metric", zap.Error(err), zap.String("database", dbName))
 		} else {
 			r.parseDatabaseMetrics(ctx, mm, dbName, dbStatsMetrics, dbStats)
 		}
 
-		serverStatus, err := client.query(ctx, dbName, bson.M{"serverStatus": 1})
-		if err != nil {
-			r.logger.Error("Failed to collect serverStatus metric", zap.Error(err), zap.String("database", dbName))
-		} else {
-			r.parseDatabaseMetrics(ctx, mm, dbName, serverStatusMetrics, serverStatus)
+		if r.config.CollectionStats.Enabled && r.config.CollectionStats.Databases.matches(dbName) {
+			r.collectCollectionMetrics(ctx, client, mm, dbName)
 		}
 	}
 
 	return rms, nil
 }
 
+// databaseNames returns the databases on the server that are selected by the databases config.
+func (r *mongodbScraper) databaseNames(ctx context.Context, client client) ([]string, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+	allNames, err := client.ListDatabaseNames(timeoutCtx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+
+	dbNames := make([]string, 0, len(allNames))
+	for _, dbName := range allNames {
+		if r.config.Databases.matches(dbName) {
+			dbNames = append(dbNames, dbName)
+		}
+	}
+	return dbNames, nil
+}
+
+// setServerResourceAttributes identifies the server that a resource's metrics were scraped from.
+func setServerResourceAttributes(resource pdata.Resource, isMaster, serverStatus bson.M) {
+	attributes := resource.Attributes()
+
+	// replica set members report the address that the rest of the set knows them by
+	address, ok := isMaster["me"].(string)
+	if !ok {
+		address, ok = serverStatus["host"].(string)
+	}
+	if ok {
+		host, port := splitServerAddress(address)
+		attributes.UpsertString(hostResourceAttribute, host)
+		attributes.UpsertInt(portResourceAttribute, port)
+	}
+	if version, ok := serverStatus["version"].(string); ok {
+		attributes.UpsertString(versionResourceAttribute, version)
+	}
+	if isMaster == nil {
+		return
+	}
+	if replicaSet, ok := isMaster["setName"].(string); ok {
+		attributes.UpsertString(replicaSetResourceAttribute, replicaSet)
+	}
+	attributes.UpsertString(roleResourceAttribute, serverRole(isMaster))
+}
+
+// splitServerAddress splits an address reported by the server, which leaves out the port when it is the default.
+func splitServerAddress(address string) (string, int64) {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, defaultPort
+	}
+	port, err := strconv.ParseInt(portString, 10, 64)
+	if err != nil {
+		return host, defaultPort
+	}
+	return host, port
+}
+
+// serverRole returns the role of the server in its replica set from the isMaster response.
+func serverRole(isMaster bson.M) string {
+	if _, ok := isMaster["setName"]; !ok {
+		return roleStandalone
+	}
+	if arbiter, _ := isMaster["arbiterOnly"].(bool); arbiter {
+		return roleArbiter
+	}
+	if primary, _ := isMaster["ismaster"].(bool); primary {
+		return rolePrimary
+	}
+	if secondary, _ := isMaster["secondary"].(bool); secondary {
+		return roleSecondary
+	}
+	return roleOther
+}
+
+// parseServerMetrics parses the metrics that describe the server as a whole from serverStatus.
+func (r *mongodbScraper) parseServerMetrics(ctx context.Context, mm *metricManager, serverStatus bson.M) {
+	r.parseSpecialMetrics(ctx, mm, serverStatus)
+	r.parseMetrics(mm, pdata.NewAttributeMap(), serverStatusMetrics, serverStatus)
+	r.parseMetrics(mm, pdata.NewAttributeMap(), operationMetrics, serverStatus)
+	if _, ok := serverStatus["wiredTiger"]; ok {
+		r.parseMetrics(mm, pdata.NewAttributeMap(), wiredTigerMetrics, serverStatus)
+	}
+}
+
 func (r *mongodbScraper) parseSpecialMetrics(ctx context.Context, mm *metricManager, document bson.M) {
 	// Collect Global Lock Wait Time
 
@@ -281,10 +656,23 @@ func (r *mongodbScraper) parseDatabaseMetrics(
 	databaseName string,
 	metricsRequested []mongoMetric,
 	document bson.M,
+) {
+	attributes := pdata.NewAttributeMap()
+	attributes.Insert(metadata.A.DatabaseName, pdata.NewAttributeValueString(databaseName))
+	r.parseMetrics(mm, attributes, metricsRequested, document)
+}
+
+// parseMetrics adds a data point for each requested metric found in document, with the given attributes
+// in addition to the static attributes of the metric.
+func (r *mongodbScraper) parseMetrics(
+	mm *metricManager,
+	baseAttributes pdata.AttributeMap,
+	metricsRequested []mongoMetric,
+	document bson.M,
 ) {
 	for _, metricRequest := range metricsRequested {
 		attributes := pdata.NewAttributeMap()
-		attributes.Insert(metadata.A.DatabaseName, pdata.NewAttributeValueString(databaseName))
+		baseAttributes.CopyTo(attributes)
 		for k, v := range metricRequest.staticAttributes {
 			attributes.Insert(k, pdata.NewAttributeValueString(v))
 		}
@@ -309,49 +697,75 @@ func (r *mongodbScraper) parseDatabaseMetrics(
 }
 
 func getIntMetricValue(document bson.M, path []string) (int64, error) {
-	curItem, remainingPath := path[0], path[1:]
-	value := document[curItem]
-	if value == nil {
-		return 0, errors.New("nil found when digging for metric")
-	} else if len(remainingPath) == 0 {
-		switch v := value.(type) {
-		case int:
-			return int64(v), nil
-		case int32:
-			return int64(v), nil
-		case int64:
-			return v, nil
-		case string:
-			return strconv.ParseInt(v, 10, 64)
-		default:
-			return 0, fmt.Errorf("unexpected type found when parsing int: %v", reflect.TypeOf(value))
-		}
-	} else {
-		return getIntMetricValue(value.(bson.M), remainingPath)
+	value, err := digValue(document, path)
+	if err != nil {
+		return 0, err
 	}
+	return parseInt(value)
 }
 
 func getDoubleMetricValue(document bson.M, path []string) (float64, error) {
+	value, err := digValue(document, path)
+	if err != nil {
+		return 0, err
+	}
+	return parseDouble(value)
+}
+
+// digValue follows path through nested documents and returns the value at the end of it.
+func digValue(document bson.M, path []string) (interface{}, error) {
 	curItem, remainingPath := path[0], path[1:]
 	value := document[curItem]
 	if value == nil {
-		return 0, errors.New("nil found when digging for metric")
+		return nil, errors.New("nil found when digging for metric")
 	} else if len(remainingPath) == 0 {
-		switch v := value.(type) {
-		case float32:
-			return float64(v), nil
-		case float64:
-			return v, nil
-		case string:
-			return strconv.ParseFloat(v, 64)
-		default:
-			return 0, fmt.Errorf("unexpected type found when parsing double: %v", reflect.TypeOf(value))
-		}
-	} else {
-		return getDoubleMetricValue(value.(bson.M), remainingPath)
+		return value, nil
+	}
+
+	subDocument, ok := value.(bson.M)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type found when digging for metric: %v", reflect.TypeOf(value))
+	}
+	return digValue(subDocument, remainingPath)
+}
+
+func parseInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		// large counters may be reported as doubles
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected type found when parsing int: %v", reflect.TypeOf(value))
 	}
 }
 
+func parseDouble(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("unexpected type found when parsing double: %v", reflect.TypeOf(value))
+	}
+}
+
+func newInstrumentationLibraryMetrics(rm pdata.ResourceMetrics) pdata.InstrumentationLibraryMetrics {
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/mongodb")
+	return ilm
+}
+
 type metricManager struct {
 	logger             *zap.Logger
 	ilm                pdata.InstrumentationLibraryMetrics
@@ -1,12 +1,17 @@
 package mongodbreceiver
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net"
 	"testing"
 
 	"github.com/observiq/opentelemetry-components/receiver/helper"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 )
 
@@ -28,3 +33,203 @@ func TestScrape(t *testing.T) {
 
 	helper.ScraperTest(t, scraper.scrape, expectedFileBytes)
 }
+
+func TestScrapeMongos(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = net.JoinHostPort("localhost", "37017")
+
+	scraper := mongodbScraper{
+		logger:      zap.NewNop(),
+		config:      cfg,
+		buildClient: createFakeMongosClient,
+	}
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscrapemongos/expected_metrics.json")
+	require.NoError(t, err)
+
+	helper.ScraperTest(t, scraper.scrape, expectedFileBytes)
+}
+
+func TestScrapeCollectionStats(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = net.JoinHostPort("localhost", "37017")
+	cfg.CollectionStats.Enabled = true
+	cfg.CollectionStats.IndexStats = true
+
+	scraper := mongodbScraper{
+		logger:      zap.NewNop(),
+		config:      cfg,
+		buildClient: createFakeClient,
+	}
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscrapecollectionstats/expected_metrics.json")
+	require.NoError(t, err)
+
+	helper.ScraperTest(t, scraper.scrape, expectedFileBytes)
+}
+
+func TestScrapeOperations(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = net.JoinHostPort("localhost", "37017")
+	cfg.Operations.CurrentOp = true
+	cfg.Operations.Top = true
+
+	scraper := mongodbScraper{
+		logger:      zap.NewNop(),
+		config:      cfg,
+		buildClient: createFakeClient,
+	}
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscrapeoperations/expected_metrics.json")
+	require.NoError(t, err)
+
+	helper.ScraperTest(t, scraper.scrape, expectedFileBytes)
+}
+
+func TestScrapeMongosShardFailure(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = net.JoinHostPort("localhost", "37017")
+
+	scraper := mongodbScraper{
+		logger: zap.NewNop(),
+		config: cfg,
+		buildClient: func(config *Config, logger *zap.Logger) (client, error) {
+			if config.replicaSet == "shard02" {
+				return nil, errors.New("connection refused")
+			}
+			return createFakeMongosClient(config, logger)
+		},
+	}
+
+	metrics, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.NotZero(t, metrics.MetricCount())
+}
+
+func TestScrapeHosts(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	for _, endpoint := range []string{"mongo-0:27017", "mongo-1:27017"} {
+		host := HostConfig{DirectConnection: true}
+		host.Endpoint = endpoint
+		cfg.Hosts = append(cfg.Hosts, host)
+	}
+
+	scraper := mongodbScraper{
+		logger:      zap.NewNop(),
+		config:      cfg,
+		buildClient: createFakeReplicaSetClient,
+	}
+
+	expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscrapehosts/expected_metrics.json")
+	require.NoError(t, err)
+
+	helper.ScraperTest(t, scraper.scrape, expectedFileBytes)
+}
+
+func TestScrapeHostsNoDuplicateSeries(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	for _, endpoint := range []string{"mongo-0:27017", "mongo-1:27017", "mongo-2:27017"} {
+		host := HostConfig{DirectConnection: true}
+		host.Endpoint = endpoint
+		cfg.Hosts = append(cfg.Hosts, host)
+	}
+
+	scraper := mongodbScraper{
+		logger:      zap.NewNop(),
+		config:      cfg,
+		buildClient: createFakeReplicaSetClient,
+	}
+
+	metrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	// Replica set members are identified by their data point attributes, so the same series
+	// must not be reported by more than one host.
+	seen := map[string]bool{}
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ms := rms.At(i).InstrumentationLibraryMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			m := ms.At(j)
+			var dps pdata.NumberDataPointSlice
+			switch m.DataType() {
+			case pdata.MetricDataTypeGauge:
+				dps = m.Gauge().DataPoints()
+			case pdata.MetricDataTypeSum:
+				dps = m.Sum().DataPoints()
+			default:
+				continue
+			}
+			for k := 0; k < dps.Len(); k++ {
+				attributes := dps.At(k).Attributes()
+				if _, ok := attributes.Get("member_host"); !ok {
+					continue
+				}
+				series := fmt.Sprintf("%s %v", m.Name(), attributes.AsRaw())
+				require.False(t, seen[series], "duplicate series %s", series)
+				seen[series] = true
+			}
+		}
+	}
+	require.NotEmpty(t, seen)
+}
+
+func TestScrapeHostsPartialFailure(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	for _, endpoint := range []string{"mongo-0:27017", "mongo-1:27017"} {
+		host := HostConfig{DirectConnection: true}
+		host.Endpoint = endpoint
+		cfg.Hosts = append(cfg.Hosts, host)
+	}
+
+	scraper := mongodbScraper{
+		logger: zap.NewNop(),
+		config: cfg,
+		buildClient: func(config *Config, logger *zap.Logger) (client, error) {
+			if config.seedList[0] == "mongo-1:27017" {
+				return nil, errors.New("connection refused")
+			}
+			return createFakeReplicaSetClient(config, logger)
+		},
+	}
+
+	metrics, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.Equal(t, 1, metrics.ResourceMetrics().Len())
+}
+
+func TestDatabaseNames(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+
+	scraper := mongodbScraper{
+		logger:      zap.NewNop(),
+		config:      cfg,
+		buildClient: createFakeClient,
+	}
+	client, err := scraper.buildClient(cfg, zap.NewNop())
+	require.NoError(t, err)
+
+	dbNames, err := scraper.databaseNames(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fakedatabase"}, dbNames)
+
+	cfg.Databases.Include = []string{"fake*"}
+	dbNames, err = scraper.databaseNames(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, []string{"fakedatabase"}, dbNames)
+
+	cfg.Databases.Exclude = []string{"fakedatabase"}
+	dbNames, err = scraper.databaseNames(context.Background(), client)
+	require.NoError(t, err)
+	require.Empty(t, dbNames)
+}
@@ -0,0 +1,217 @@
+package mongodbreceiver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/observiq/opentelemetry-components/receiver/mongodbreceiver/internal/metadata"
+)
+
+const (
+	// shardResourceAttribute identifies the shard a resource's metrics were scraped from.
+	shardResourceAttribute = "mongodb.shard"
+
+	// configShardName is used as the shard name of the config server replica set.
+	configShardName = "config"
+)
+
+type shard struct {
+	name string
+	// host is in the "replicaSet/host1:port,host2:port" form.
+	host string
+}
+
+// isMongos reports whether a server is a mongos router rather than a mongod from its isMaster response.
+func isMongos(isMaster bson.M) bool {
+	msg, _ := isMaster["msg"].(string)
+	return msg == "isdbgrid"
+}
+
+// collectShardedMetrics reports cluster-wide metrics from the mongos router, then scrapes the primary of
+// each shard and of the config server replica set under a resource of its own.
+func (r *mongodbScraper) collectShardedMetrics(ctx context.Context, client client) (pdata.Metrics, error) {
+	rms := pdata.NewMetrics()
+	mm := newMetricManager(r.logger, newInstrumentationLibraryMetrics(rms.ResourceMetrics().AppendEmpty()))
+
+	r.collectBalancerMetrics(ctx, client, mm)
+	r.collectChunkMetrics(ctx, client, mm)
+
+	// the metrics of every shard that could be scraped are still reported when others are down
+	var errs scrapererror.ScrapeErrors
+	shards, err := listShards(ctx, client)
+	if err != nil {
+		r.logger.Error("Failed to list shards", zap.Error(err))
+		errs.AddPartial(len(metadata.M.Names()), err)
+	}
+
+	configServers, err := configServerHost(ctx, client)
+	if err != nil {
+		r.logger.Error("Failed to find config servers", zap.Error(err))
+		errs.AddPartial(len(metadata.M.Names()), err)
+	} else {
+		shards = append(shards, shard{name: configShardName, host: configServers})
+	}
+
+	for _, s := range shards {
+		shardMetrics, err := r.scrapeShard(ctx, s)
+		if err != nil {
+			r.logger.Error("Failed to scrape shard", zap.Error(err), zap.String("shard", s.name))
+			errs.AddPartial(len(metadata.M.Names()), err)
+			continue
+		}
+		shardMetrics.ResourceMetrics().MoveAndAppendTo(rms.ResourceMetrics())
+	}
+
+	return rms, errs.Combine()
+}
+
+func (r *mongodbScraper) scrapeShard(ctx context.Context, s shard) (pdata.Metrics, error) {
+	client, err := r.connect(ctx, r.config.forShard(s.host))
+	if err != nil {
+		return pdata.NewMetrics(), err
+	}
+	defer r.disconnect(ctx, client)
+
+	isMaster, err := client.query(ctx, "admin", bson.D{{Key: "isMaster", Value: 1}})
+	if err != nil {
+		r.logger.Error("Failed to query isMaster in admin", zap.Error(err), zap.String("shard", s.name))
+	}
+
+	shardMetrics, err := r.collectMetrics(ctx, client, isMaster)
+	if err != nil {
+		return pdata.NewMetrics(), err
+	}
+
+	rms := shardMetrics.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rms.At(i).Resource().Attributes().UpsertString(shardResourceAttribute, s.name)
+	}
+	return shardMetrics, nil
+}
+
+func listShards(ctx context.Context, client client) ([]shard, error) {
+	document, err := client.query(ctx, "admin", bson.D{{Key: "listShards", Value: 1}})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, ok := document["shards"].(bson.A)
+	if !ok {
+		return nil, errors.New("failed to find shards in listShards")
+	}
+
+	shards := make([]shard, 0, len(entries))
+	for _, e := range entries {
+		entry, ok := e.(bson.M)
+		if !ok {
+			continue
+		}
+		name, _ := entry["_id"].(string)
+		host, _ := entry["host"].(string)
+		shards = append(shards, shard{name: name, host: host})
+	}
+	return shards, nil
+}
+
+// configServerHost returns the connection string of the config server replica set that mongos is using.
+func configServerHost(ctx context.Context, client client) (string, error) {
+	serverStatus, err := client.query(ctx, "admin", bson.D{{Key: "serverStatus", Value: 1}})
+	if err != nil {
+		return "", err
+	}
+
+	value, err := digValue(serverStatus, []string{"sharding", "configsvrConnectionString"})
+	if err != nil {
+		return "", err
+	}
+	host, ok := value.(string)
+	if !ok {
+		return "", errors.New("unexpected type found for configsvrConnectionString")
+	}
+	return host, nil
+}
+
+func (r *mongodbScraper) collectBalancerMetrics(ctx context.Context, client client, mm *metricManager) {
+	status, err := client.query(ctx, "admin", bson.D{{Key: "balancerStatus", Value: 1}})
+	if err != nil {
+		r.logger.Error("Failed to query balancerStatus in admin", zap.Error(err))
+		return
+	}
+
+	if mode, ok := status["mode"].(string); ok {
+		mm.addIntDataPoint(metadata.M.MongodbBalancerEnabled, boolToInt(mode == "full"), pdata.NewAttributeMap())
+	}
+	if inRound, ok := status["inBalancerRound"].(bool); ok {
+		mm.addIntDataPoint(metadata.M.MongodbBalancerRunning, boolToInt(inRound), pdata.NewAttributeMap())
+	}
+}
+
+// chunkCountPipeline counts chunks per collection and shard. Chunks only reference their collection by
+// uuid as of MongoDB 5.0, so the namespace is looked up in config.collections when it is missing.
+var chunkCountPipeline = mongo.Pipeline{
+	{{Key: "$lookup", Value: bson.D{
+		{Key: "from", Value: "collections"},
+		{Key: "localField", Value: "uuid"},
+		{Key: "foreignField", Value: "uuid"},
+		{Key: "as", Value: "collection"},
+	}}},
+	{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: bson.D{
+			{Key: "ns", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$ns", bson.D{{Key: "$arrayElemAt", Value: bson.A{"$collection._id", 0}}}}}}},
+			{Key: "shard", Value: "$shard"},
+		}},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}},
+}
+
+func (r *mongodbScraper) collectChunkMetrics(ctx context.Context, client client, mm *metricManager) {
+	groups, err := client.aggregate(ctx, "config", "chunks", chunkCountPipeline)
+	if err != nil {
+		r.logger.Error("Failed to count chunks in config", zap.Error(err))
+		return
+	}
+
+	for _, group := range groups {
+		id, ok := group["_id"].(bson.M)
+		if !ok {
+			continue
+		}
+		namespace, _ := id["ns"].(string)
+		shardName, _ := id["shard"].(string)
+		count, err := parseInt(group["count"])
+		if err != nil {
+			r.logger.Error("Failed to Parse", zap.Error(err), zap.String("metric", metadata.M.MongodbChunks.Name()))
+			continue
+		}
+
+		database, collection := splitNamespace(namespace)
+		attributes := pdata.NewAttributeMap()
+		attributes.Insert(metadata.A.DatabaseName, pdata.NewAttributeValueString(database))
+		attributes.Insert(metadata.A.CollectionName, pdata.NewAttributeValueString(collection))
+		attributes.Insert(metadata.A.ShardName, pdata.NewAttributeValueString(shardName))
+		mm.addIntDataPoint(metadata.M.MongodbChunks, count, attributes)
+	}
+}
+
+// splitNamespace splits a "database.collection" namespace. Collection names may themselves contain dots.
+func splitNamespace(namespace string) (string, string) {
+	parts := strings.SplitN(namespace, ".", 2)
+	if len(parts) < 2 {
+		return namespace, ""
+	}
+	return parts[0], parts[1]
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
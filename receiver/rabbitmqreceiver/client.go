@@ -0,0 +1,173 @@
+package rabbitmqreceiver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+var (
+	// errUnauthorized is wrapped by the errors of requests rejected for their credentials.
+	errUnauthorized = errors.New("unauthorized")
+	// errNotFound is wrapped by the errors of requests to paths that do not exist, such as a missing vhost.
+	errNotFound = errors.New("not found")
+	// errServer is wrapped by the errors of requests that failed on the server.
+	errServer = errors.New("server error")
+)
+
+// rabbitmqClient reads the management API and the prometheus endpoint for every scrape.
+type rabbitmqClient struct {
+	client *http.Client
+	cfg    *Config
+	logger *zap.Logger
+}
+
+func newRabbitMQClient(host component.Host, cfg *Config, logger *zap.Logger) (*rabbitmqClient, error) {
+	client, err := cfg.ToClient(host.GetExtensions())
+	if err != nil {
+		return nil, err
+	}
+
+	return &rabbitmqClient{
+		client: client,
+		cfg:    cfg,
+		logger: logger,
+	}, nil
+}
+
+func basicAuth(username, password string) string {
+	auth := username + ":" + password
+	return base64.StdEncoding.EncodeToString([]byte(auth))
+}
+
+// get reads the response body of the request to path.
+func (c *rabbitmqClient) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.cfg.Endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the prometheus endpoint does not require credentials
+	if c.cfg.Username != "" {
+		req.Header.Add("Authorization", "Basic "+basicAuth(c.cfg.Username, c.cfg.Password))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.logger.Error("failed to close client response", zap.Error(err))
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newHTTPError(path, resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+// getJSON reads the response of the management API at path into v.
+func (c *rabbitmqClient) getJSON(ctx context.Context, path string, v interface{}) error {
+	body, err := c.get(ctx, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// page is a page of a paginated list of the management API.
+type page struct {
+	Items     []interface{} `json:"items"`
+	PageCount int           `json:"page_count"`
+}
+
+// getPages reads every page of the paginated list of the management API at path.
+func (c *rabbitmqClient) getPages(ctx context.Context, path string) ([]interface{}, error) {
+	var items []interface{}
+	for pageNumber := 1; ; pageNumber++ {
+		var p page
+		if err := c.getJSON(ctx, fmt.Sprintf("%s?page=%d&page_size=%d", path, pageNumber, c.cfg.PageSize), &p); err != nil {
+			return nil, err
+		}
+		items = append(items, p.Items...)
+		if pageNumber >= p.PageCount {
+			return items, nil
+		}
+	}
+}
+
+// getVhostPages reads every page of the list of the management API at path, or of its per-vhost lists
+// if vhosts are configured.
+func (c *rabbitmqClient) getVhostPages(ctx context.Context, path string) ([]interface{}, error) {
+	if len(c.cfg.Vhosts) == 0 {
+		return c.getPages(ctx, path)
+	}
+	var items []interface{}
+	for _, vhost := range c.cfg.Vhosts {
+		vhostItems, err := c.getPages(ctx, path+"/"+url.PathEscape(vhost))
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, vhostItems...)
+	}
+	return items, nil
+}
+
+// httpError is returned for requests that RabbitMQ responded to with a non-2xx status code.
+type httpError struct {
+	path       string
+	statusCode int
+	// reason is read from the error in the response body of the management API, if there is one.
+	reason string
+}
+
+func newHTTPError(path string, statusCode int, body []byte) *httpError {
+	httpErr := &httpError{path: path, statusCode: statusCode}
+
+	var errorBody struct {
+		Error  string `json:"error"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(body, &errorBody); err == nil {
+		httpErr.reason = errorBody.Reason
+		if httpErr.reason == "" {
+			httpErr.reason = errorBody.Error
+		}
+	}
+	return httpErr
+}
+
+func (e *httpError) Error() string {
+	msg := fmt.Sprintf("request to %s failed with status %d", e.path, e.statusCode)
+	if e.reason != "" {
+		msg += fmt.Sprintf(": %s", e.reason)
+	}
+	return msg
+}
+
+// Unwrap returns errUnauthorized, errNotFound or errServer for the status codes they stand for.
+func (e *httpError) Unwrap() error {
+	switch {
+	case e.statusCode == http.StatusUnauthorized:
+		return errUnauthorized
+	case e.statusCode == http.StatusNotFound:
+		return errNotFound
+	case e.statusCode >= 500:
+		return errServer
+	}
+	return nil
+}
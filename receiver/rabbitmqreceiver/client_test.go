@@ -0,0 +1,92 @@
+package rabbitmqreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+)
+
+func TestClientHTTPErrors(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		statusCode  int
+		body        string
+		expected    error
+		expectedMsg string
+	}{
+		{
+			desc:        "unauthorized",
+			statusCode:  401,
+			body:        `{"error":"not_authorised","reason":"Login failed"}`,
+			expected:    errUnauthorized,
+			expectedMsg: "request to /api/queues?page=1&page_size=500 failed with status 401: Login failed",
+		},
+		{
+			desc:        "not found",
+			statusCode:  404,
+			body:        `{"error":"Object Not Found","reason":"Not Found"}`,
+			expected:    errNotFound,
+			expectedMsg: "request to /api/queues?page=1&page_size=500 failed with status 404: Not Found",
+		},
+		{
+			desc:        "server error",
+			statusCode:  503,
+			body:        `<html>Service Unavailable</html>`,
+			expected:    errServer,
+			expectedMsg: "request to /api/queues?page=1&page_size=500 failed with status 503",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			rabbitmqMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tC.statusCode)
+				_, err := rw.Write([]byte(tC.body))
+				require.NoError(t, err)
+			}))
+			defer rabbitmqMock.Close()
+
+			sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: rabbitmqMock.URL,
+				},
+				Username: "dev",
+				Password: "dev",
+				PageSize: 500,
+			})
+			require.NoError(t, err)
+			require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+
+			_, err = sc.scrape(context.Background())
+			require.True(t, errors.Is(err, tC.expected))
+			require.EqualError(t, err, tC.expectedMsg)
+		})
+	}
+}
+
+func TestClientCanceledContext(t *testing.T) {
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/api/queues": "./testdata/exampleAPICall.json",
+	})
+	sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: rabbitmqMock.URL,
+		},
+		Username: "dev",
+		Password: "dev",
+		PageSize: 500,
+	})
+	require.NoError(t, err)
+	require.NoError(t, sc.start(context.Background(), componenttest.NewNopHost()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = sc.scrape(ctx)
+	require.True(t, errors.Is(err, context.Canceled))
+}
@@ -3,6 +3,8 @@ package rabbitmqreceiver
 import (
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -15,20 +17,161 @@ type Config struct {
 
 	Password string `mapstructure:"password"`
 	Username string `mapstructure:"username"`
+
+	// Mode is "management" to read the management API, or "prometheus" to read the text exposition format
+	// of the rabbitmq_prometheus plugin.
+	Mode string `mapstructure:"mode"`
+	// Prometheus configures the prometheus mode.
+	Prometheus PrometheusConfig `mapstructure:"prometheus"`
+
+	// Vhosts limits the queues and exchanges collected to these virtual hosts. All are collected if empty.
+	Vhosts []string `mapstructure:"vhosts"`
+	// Queues selects the queues collected by name.
+	Queues QueuesConfig `mapstructure:"queues"`
+	// PageSize is the number of queues, exchanges, connections or channels read from each page of the
+	// management API.
+	PageSize int `mapstructure:"page_size"`
+
+	// Exchanges enables the publish rates of each exchange from /api/exchanges.
+	Exchanges ExchangesConfig `mapstructure:"exchanges"`
+	// Connections enables connection and channel metrics from /api/connections and /api/channels.
+	Connections ConnectionsConfig `mapstructure:"connections"`
+}
+
+// Values of Config.Mode.
+const (
+	modeManagement = "management"
+	modePrometheus = "prometheus"
+)
+
+type PrometheusConfig struct {
+	// Path is "/metrics/per-object" to report metrics per queue and channel, or "/metrics" to report the
+	// totals of the node.
+	Path string `mapstructure:"path"`
+}
+
+// maxPageSize is the largest page size accepted by the management API.
+const maxPageSize = 500
+
+// QueuesConfig selects the queues collected with regular expressions matched against the whole queue name.
+// A queue is selected if it matches any include pattern, or there are none, and does not match any exclude
+// pattern.
+type QueuesConfig struct {
+	Include []string `mapstructure:"include"`
+	Exclude []string `mapstructure:"exclude"`
+}
+
+type ExchangesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// Values of ConnectionsConfig.AggregateBy.
+const (
+	aggregateByChannel = "channel"
+	aggregateByUser    = "user"
+	aggregateByVhost   = "vhost"
+)
+
+type ConnectionsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// AggregateBy is "channel" to report the unacknowledged messages and prefetch of each channel, or
+	// "user" or "vhost" to sum them per user or vhost instead. Connections and channels are also
+	// counted per user or vhost then.
+	AggregateBy string `mapstructure:"aggregate_by"`
 }
 
 func (cfg *Config) Validate() error {
 	var errs []error
-	if cfg.Username == "" {
-		errs = append(errs, fmt.Errorf("missing required field 'username'"))
-	}
-	if cfg.Password == "" {
-		errs = append(errs, fmt.Errorf("missing required field 'password'"))
+	switch cfg.Mode {
+	case modeManagement:
+		if cfg.Username == "" {
+			errs = append(errs, fmt.Errorf("missing required field 'username'"))
+		}
+		if cfg.Password == "" {
+			errs = append(errs, fmt.Errorf("missing required field 'password'"))
+		}
+	case modePrometheus:
+		// the prometheus endpoint only lists metrics, so it does not require credentials
+		if !strings.HasPrefix(cfg.Prometheus.Path, "/") {
+			errs = append(errs, fmt.Errorf("invalid value '%s' for field 'prometheus.path', must start with '/'", cfg.Prometheus.Path))
+		}
+		if cfg.Connections.AggregateBy != aggregateByChannel {
+			errs = append(errs, fmt.Errorf("'connections.aggregate_by' must be 'channel' in prometheus mode"))
+		}
+		// the totals of the node include every queue, so the queues could not be selected
+		if strings.TrimSuffix(cfg.Prometheus.Path, "/") == "/metrics" && (len(cfg.Vhosts) > 0 || len(cfg.Queues.Include) > 0 || len(cfg.Queues.Exclude) > 0) {
+			errs = append(errs, fmt.Errorf("'vhosts' and 'queues' require 'prometheus.path' to report metrics per queue, such as '/metrics/per-object'"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid value '%s' for field 'mode', must be one of 'management' or 'prometheus'", cfg.Mode))
 	}
 	if cfg.Endpoint == "" {
 		errs = append(errs, fmt.Errorf("missing required field 'endpoint'"))
 	} else if _, err := url.Parse(cfg.Endpoint); err != nil {
 		errs = append(errs, fmt.Errorf("invalid url specified in field 'endpoint'"))
 	}
+	for _, vhost := range cfg.Vhosts {
+		if vhost == "" {
+			errs = append(errs, fmt.Errorf("'vhosts' must not contain empty vhosts"))
+			break
+		}
+	}
+	if _, err := cfg.Queues.filter(); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.PageSize < 1 || cfg.PageSize > maxPageSize {
+		errs = append(errs, fmt.Errorf("invalid value %d for field 'page_size', must be between 1 and %d", cfg.PageSize, maxPageSize))
+	}
+	switch cfg.Connections.AggregateBy {
+	case aggregateByChannel, aggregateByUser, aggregateByVhost:
+	default:
+		errs = append(errs, fmt.Errorf("invalid value '%s' for field 'connections.aggregate_by', must be one of 'channel', 'user' or 'vhost'", cfg.Connections.AggregateBy))
+	}
 	return multierr.Combine(errs...)
 }
+
+// queueFilter is the compiled form of QueuesConfig.
+type queueFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func (qc QueuesConfig) filter() (*queueFilter, error) {
+	include, err := compilePatterns(qc.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compilePatterns(qc.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	return &queueFilter{include: include, exclude: exclude}, nil
+}
+
+func (qf *queueFilter) matches(queue string) bool {
+	if len(qf.include) > 0 && !matchesAny(qf.include, queue) {
+		return false
+	}
+	return !matchesAny(qf.exclude, queue)
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid queue pattern '%s'", pattern)
+		}
+		regexps = append(regexps, re)
+	}
+	return regexps, nil
+}
+
+func matchesAny(regexps []*regexp.Regexp, name string) bool {
+	for _, re := range regexps {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package rabbitmqreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			desc:   "default with credentials",
+			modify: func(cfg *Config) {},
+		},
+		{
+			desc: "missing credentials",
+			modify: func(cfg *Config) {
+				cfg.Username = ""
+				cfg.Password = ""
+			},
+			expected: "missing required field 'username'; missing required field 'password'",
+		},
+		{
+			desc: "prometheus mode without credentials",
+			modify: func(cfg *Config) {
+				cfg.Mode = modePrometheus
+				cfg.Username = ""
+				cfg.Password = ""
+			},
+		},
+		{
+			desc: "invalid mode",
+			modify: func(cfg *Config) {
+				cfg.Mode = "metrics"
+			},
+			expected: "invalid value 'metrics' for field 'mode', must be one of 'management' or 'prometheus'",
+		},
+		{
+			desc: "prometheus mode aggregated by user",
+			modify: func(cfg *Config) {
+				cfg.Mode = modePrometheus
+				cfg.Connections.AggregateBy = aggregateByUser
+			},
+			expected: "'connections.aggregate_by' must be 'channel' in prometheus mode",
+		},
+		{
+			desc: "prometheus node totals with queue filters",
+			modify: func(cfg *Config) {
+				cfg.Mode = modePrometheus
+				cfg.Prometheus.Path = "/metrics"
+				cfg.Vhosts = []string{"dev"}
+			},
+			expected: "'vhosts' and 'queues' require 'prometheus.path' to report metrics per queue, such as '/metrics/per-object'",
+		},
+		{
+			desc: "prometheus per-object with queue filters",
+			modify: func(cfg *Config) {
+				cfg.Mode = modePrometheus
+				cfg.Queues.Exclude = []string{"amq\\..*"}
+			},
+		},
+		{
+			desc: "empty vhost",
+			modify: func(cfg *Config) {
+				cfg.Vhosts = []string{"dev", ""}
+			},
+			expected: "'vhosts' must not contain empty vhosts",
+		},
+		{
+			desc: "invalid queue pattern",
+			modify: func(cfg *Config) {
+				cfg.Queues.Exclude = []string{"webq("}
+			},
+			expected: "invalid queue pattern 'webq('",
+		},
+		{
+			desc: "page size too large",
+			modify: func(cfg *Config) {
+				cfg.PageSize = 1000
+			},
+			expected: "invalid value 1000 for field 'page_size', must be between 1 and 500",
+		},
+		{
+			desc: "invalid aggregation",
+			modify: func(cfg *Config) {
+				cfg.Connections.AggregateBy = "node"
+			},
+			expected: "invalid value 'node' for field 'connections.aggregate_by', must be one of 'channel', 'user' or 'vhost'",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Username = "dev"
+			cfg.Password = "dev"
+			tC.modify(cfg)
+			err := cfg.Validate()
+			if tC.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tC.expected)
+			}
+		})
+	}
+}
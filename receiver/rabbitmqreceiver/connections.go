@@ -0,0 +1,140 @@
+package rabbitmqreceiver
+
+import (
+	"context"
+
+	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// connectionMetricsCount and channelMetricsCount are the number of metrics missing if the connections or
+// channels cannot be read.
+const (
+	connectionMetricsCount = 1
+	channelMetricsCount    = 3
+)
+
+// connectionKey identifies a group of connections or channels. Fields that are not aggregated on are empty.
+type connectionKey struct {
+	name  string
+	state string
+	user  string
+	vhost string
+}
+
+// attributes returns the non-empty fields of the key as attributes, the name being the channel name.
+func (k connectionKey) attributes() pdata.AttributeMap {
+	attributes := pdata.NewAttributeMap()
+	for attribute, value := range map[string]string{
+		metadata.A.Channel:         k.name,
+		metadata.A.ConnectionState: k.state,
+		metadata.A.User:            k.user,
+		metadata.A.Vhost:           k.vhost,
+	} {
+		if value != "" {
+			attributes.Upsert(attribute, pdata.NewAttributeValueString(value))
+		}
+	}
+	return attributes
+}
+
+// groupKey returns the key of a connection or channel that its count is aggregated on.
+func (r *rabbitmqScraper) groupKey(body map[string]interface{}) connectionKey {
+	key := connectionKey{}
+	key.state, _ = body["state"].(string)
+	switch r.cfg.Connections.AggregateBy {
+	case aggregateByUser:
+		key.user, _ = body["user"].(string)
+	case aggregateByVhost:
+		key.vhost, _ = body["vhost"].(string)
+	}
+	return key
+}
+
+// scrapeConnections reports the number of connections in each state from /api/connections.
+func (r *rabbitmqScraper) scrapeConnections(ctx context.Context, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	connections, err := r.client.getPages(ctx, "/api/connections")
+	if err != nil {
+		return err
+	}
+
+	connectionsMetric := initMetric(ms, metadata.M.RabbitmqConnections).Gauge().DataPoints()
+
+	counts := map[connectionKey]float64{}
+	for _, v := range connections {
+		connection, ok := v.(map[string]interface{})
+		if !ok {
+			r.logger.Info("rabbitMQ api response format did not meet expectations")
+			break
+		}
+		counts[r.groupKey(connection)]++
+	}
+	for key, count := range counts {
+		addToDoubleMetric(connectionsMetric, key.attributes(), count, now)
+	}
+
+	return nil
+}
+
+// scrapeChannels reports the number of channels in each state, and the unacknowledged messages and
+// prefetch of each channel, or their sums per user or vhost, from /api/channels.
+func (r *rabbitmqScraper) scrapeChannels(ctx context.Context, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	channels, err := r.client.getPages(ctx, "/api/channels")
+	if err != nil {
+		return err
+	}
+
+	channelsMetric := initMetric(ms, metadata.M.RabbitmqChannels).Gauge().DataPoints()
+	channelMetrics := []struct {
+		keys   []string
+		metric pdata.NumberDataPointSlice
+		values map[connectionKey]float64
+	}{
+		{[]string{"messages_unacknowledged"}, initMetric(ms, metadata.M.RabbitmqChannelUnacknowledgedMessages).Gauge().DataPoints(), map[connectionKey]float64{}},
+		{[]string{"prefetch_count"}, initMetric(ms, metadata.M.RabbitmqChannelPrefetch).Gauge().DataPoints(), map[connectionKey]float64{}},
+	}
+
+	counts := map[connectionKey]float64{}
+	for _, v := range channels {
+		channel, ok := v.(map[string]interface{})
+		if !ok {
+			r.logger.Info("rabbitMQ api response format did not meet expectations")
+			break
+		}
+		groupKey := r.groupKey(channel)
+		counts[groupKey]++
+
+		key := connectionKey{user: groupKey.user, vhost: groupKey.vhost}
+		if r.cfg.Connections.AggregateBy == aggregateByChannel {
+			key.name, ok = channel["name"].(string)
+			if !ok {
+				r.logger.Info("could not parse channel name from body")
+				continue
+			}
+			key.user, _ = channel["user"].(string)
+			key.vhost, _ = channel["vhost"].(string)
+		}
+		for _, channelMetric := range channelMetrics {
+			val, err := getValFromBody(channelMetric.keys, channel)
+			if err != nil {
+				r.logger.Info(
+					err.Error(),
+					zap.Strings("keys", channelMetric.keys),
+				)
+				continue
+			}
+			channelMetric.values[key] += val
+		}
+	}
+	for key, count := range counts {
+		addToDoubleMetric(channelsMetric, key.attributes(), count, now)
+	}
+	for _, channelMetric := range channelMetrics {
+		for key, val := range channelMetric.values {
+			addToDoubleMetric(channelMetric.metric, key.attributes(), val, now)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package rabbitmqreceiver
+
+import (
+	"context"
+
+	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// exchangeMetricsCount is the number of metrics missing if the exchanges cannot be read.
+const exchangeMetricsCount = 1
+
+// exchangeDirections maps the publish rates of /api/exchanges to the direction attribute.
+var exchangeDirections = map[string]string{
+	"publish_in_details":  metadata.AttributeDirection.In,
+	"publish_out_details": metadata.AttributeDirection.Out,
+}
+
+// scrapeExchanges reports the publish rates of each exchange from /api/exchanges.
+func (r *rabbitmqScraper) scrapeExchanges(ctx context.Context, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	exchanges, err := r.client.getVhostPages(ctx, "/api/exchanges")
+	if err != nil {
+		return err
+	}
+
+	publishRateMetric := initMetric(ms, metadata.M.RabbitmqExchangePublishRate).Gauge().DataPoints()
+
+	for _, v := range exchanges {
+		exchange, ok := v.(map[string]interface{})
+		if !ok {
+			r.logger.Info("rabbitMQ api response format did not meet expectations")
+			break
+		}
+		attributes := pdata.NewAttributeMap()
+
+		// the default exchange has an empty name
+		exchangeName, ok := exchange["name"].(string)
+		if !ok {
+			r.logger.Info("could not parse exchange name from body")
+			break
+		}
+		vhost, _ := exchange["vhost"].(string)
+		attributes.Upsert(metadata.A.Vhost, pdata.NewAttributeValueString(vhost))
+		attributes.Upsert(metadata.A.Exchange, pdata.NewAttributeValueString(exchangeName))
+
+		// exchanges only report message stats once messages went through them
+		if _, ok := exchange["message_stats"]; !ok {
+			continue
+		}
+
+		for field, direction := range exchangeDirections {
+			val, err := getValFromBody([]string{"message_stats", field, "rate"}, exchange)
+			if err != nil {
+				r.logger.Info(
+					err.Error(),
+					zap.String("metric", "exchange.publish_rate direction:"+direction),
+				)
+				continue
+			}
+			attributes.Upsert(metadata.A.Direction, pdata.NewAttributeValueString(direction))
+			addToDoubleMetric(publishRateMetric, attributes, val, now)
+		}
+	}
+
+	return nil
+}
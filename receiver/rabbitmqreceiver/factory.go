@@ -36,6 +36,14 @@ func createDefaultConfig() config.Receiver {
 			Endpoint: "localhost:15672",
 			Timeout:  10 * time.Second,
 		},
+		Mode: modeManagement,
+		Prometheus: PrometheusConfig{
+			Path: "/metrics/per-object",
+		},
+		PageSize: maxPageSize,
+		Connections: ConnectionsConfig{
+			AggregateBy: aggregateByChannel,
+		},
 	}
 }
 
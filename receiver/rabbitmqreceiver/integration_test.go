@@ -34,6 +34,8 @@ func TestRabbitMQScraperHappyPath(t *testing.T) {
 	cfg.Endpoint = fmt.Sprintf("http://%s", net.JoinHostPort(hostname, "15672"))
 	cfg.Password = "dev"
 	cfg.Username = "dev"
+	cfg.Exchanges.Enabled = true
+	cfg.Connections.Enabled = true
 
 	consumer := new(consumertest.MetricsSink)
 	settings := componenttest.NewNopReceiverCreateSettings()
@@ -47,6 +49,8 @@ func TestRabbitMQScraperHappyPath(t *testing.T) {
 
 	md := consumer.AllMetrics()[0]
 	require.Equal(t, 1, md.ResourceMetrics().Len())
+	_, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get(clusterNameResourceAttribute)
+	require.True(t, ok)
 	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
 	require.Equal(t, 1, ilms.Len())
 	metrics := ilms.At(0).Metrics()
@@ -55,13 +59,66 @@ func TestRabbitMQScraperHappyPath(t *testing.T) {
 	validateResult(t, metrics)
 }
 
+func TestRabbitMQScraperPrometheus(t *testing.T) {
+	container := getContainer(t, containerRequest3_8)
+	defer func() {
+		require.NoError(t, container.Terminate(context.Background()))
+	}()
+	hostname, err := container.Host(context.Background())
+	require.NoError(t, err)
+
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = fmt.Sprintf("http://%s", net.JoinHostPort(hostname, "15692"))
+	cfg.Mode = modePrometheus
+
+	consumer := new(consumertest.MetricsSink)
+	settings := componenttest.NewNopReceiverCreateSettings()
+	rcvr, err := f.CreateMetricsReceiver(context.Background(), settings, cfg, consumer)
+
+	require.NoError(t, err, "failed creating metrics receiver")
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventuallyf(t, func() bool {
+		return len(consumer.AllMetrics()) > 0
+	}, 2*time.Minute, 1*time.Second, "failed to receive more than 0 metrics")
+
+	md := consumer.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	_, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get(clusterNameResourceAttribute)
+	require.True(t, ok)
+	ilms := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+	metrics := ilms.At(0).Metrics()
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// the queue and node metrics have the same names and attributes as in management mode
+	found := map[string]bool{}
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		require.Contains(t, metadata.M.Names(), m.Name())
+		require.Equal(t, metadata.M.ByName(m.Name()).New().DataType(), m.DataType())
+		if m.DataType() == pdata.MetricDataTypeGauge && m.Gauge().DataPoints().Len() > 0 {
+			found[m.Name()] = true
+		}
+	}
+	for _, name := range []string{
+		metadata.M.RabbitmqConsumers.Name(),
+		metadata.M.RabbitmqNumMessages.Name(),
+		metadata.M.RabbitmqNodeMemoryUsed.Name(),
+		metadata.M.RabbitmqNodeDiskFree.Name(),
+		metadata.M.RabbitmqNodeAlarm.Name(),
+	} {
+		require.True(t, found[name], name)
+	}
+}
+
 var (
 	containerRequest3_8 = testcontainers.ContainerRequest{
 		FromDockerfile: testcontainers.FromDockerfile{
 			Context:    path.Join(".", "testdata"),
 			Dockerfile: "Dockerfile.rabbitmq",
 		},
-		ExposedPorts: []string{"15672:15672"},
+		ExposedPorts: []string{"15672:15672", "15692:15692"},
 		WaitingFor: wait.ForListeningPort("15672").
 			WithStartupTimeout(2 * time.Minute),
 	}
@@ -84,16 +141,33 @@ func getContainer(t *testing.T, req testcontainers.ContainerRequest) testcontain
 	return container
 }
 
+// prometheusOnlyMetrics are only reported in prometheus mode.
+var prometheusOnlyMetrics = []string{
+	metadata.M.RabbitmqQueueRaftTerm.Name(),
+}
+
 func validateResult(t *testing.T, metrics pdata.MetricSlice) {
-	require.Equal(t, len(metadata.M.Names()), metrics.Len())
+	require.Equal(t, len(metadata.M.Names())-len(prometheusOnlyMetrics), metrics.Len())
 	exists := make(map[string]bool)
 
 	unenumAttributeSet := []string{
 		metadata.A.Queue,
+		metadata.A.Node,
+		metadata.A.Exchange,
+		metadata.A.Vhost,
+		metadata.A.Channel,
+		metadata.A.User,
 	}
 
 	enumAttributeSet := []string{
 		metadata.A.State,
+		metadata.A.AlarmType,
+		metadata.A.Direction,
+		metadata.A.ConnectionState,
+		metadata.A.Type,
+		metadata.A.Durability,
+		metadata.A.ObjectType,
+		metadata.A.Operation,
 	}
 
 	for i := 0; i < metrics.Len(); i++ {
@@ -133,11 +207,49 @@ func validateResult(t *testing.T, metrics pdata.MetricSlice) {
 
 	// TODO: uncomment These when load gen is added
 	require.Equal(t, map[string]bool{
-		"rabbitmq.consumers queue": true,
-		// "rabbitmq.delivery_rate queue":               true,
-		// "rabbitmq.publish_rate queue":                true,
-		"rabbitmq.num_messages queue unacknowledged": true,
-		"rabbitmq.num_messages queue ready":          true,
-		"rabbitmq.num_messages queue total":          true,
+		"rabbitmq.consumers queue node vhost classic durable": true,
+		// "rabbitmq.delivery_rate queue node vhost classic durable":               true,
+		// "rabbitmq.publish_rate queue node vhost classic durable":                true,
+		"rabbitmq.num_messages queue node vhost unacknowledged classic durable":        true,
+		"rabbitmq.num_messages queue node vhost ready classic durable":                 true,
+		"rabbitmq.num_messages queue node vhost total classic durable":                 true,
+		"rabbitmq.queue.memory queue node vhost classic durable":                       true,
+		"rabbitmq.queue.message_bytes queue node vhost total classic durable":          true,
+		"rabbitmq.queue.message_bytes queue node vhost unacknowledged classic durable": true,
+		"rabbitmq.queue.message_bytes queue node vhost ready classic durable":          true,
+		// "rabbitmq.consumer_utilisation queue node vhost classic durable": true,
+		"rabbitmq.cluster.messages total":          true,
+		"rabbitmq.cluster.messages unacknowledged": true,
+		"rabbitmq.cluster.messages ready":          true,
+		"rabbitmq.cluster.objects channel":         true,
+		"rabbitmq.cluster.objects connection":      true,
+		"rabbitmq.cluster.objects consumer":        true,
+		"rabbitmq.cluster.objects exchange":        true,
+		"rabbitmq.cluster.objects queue":           true,
+		// "rabbitmq.cluster.message_rate publish": true,
+		"rabbitmq.node.memory.used node":            true,
+		"rabbitmq.node.memory.limit node":           true,
+		"rabbitmq.node.disk.free node":              true,
+		"rabbitmq.node.disk.free_limit node":        true,
+		"rabbitmq.node.file_descriptors.used node":  true,
+		"rabbitmq.node.file_descriptors.limit node": true,
+		"rabbitmq.node.sockets.used node":           true,
+		"rabbitmq.node.sockets.limit node":          true,
+		"rabbitmq.node.erlang_processes.used node":  true,
+		"rabbitmq.node.erlang_processes.limit node": true,
+		"rabbitmq.node.run_queue node":              true,
+		"rabbitmq.node.alarm node memory":           true,
+		"rabbitmq.node.alarm node disk":             true,
+		// "rabbitmq.message.published queue node vhost classic durable":    true,
+		// "rabbitmq.message.delivered queue node vhost classic durable":    true,
+		// "rabbitmq.message.acknowledged queue node vhost classic durable": true,
+		// "rabbitmq.message.redelivered queue node vhost classic durable":  true,
+		// "rabbitmq.message.dropped":                                       true,
+		// "rabbitmq.exchange.publish_rate exchange vhost in":  true,
+		// "rabbitmq.exchange.publish_rate exchange vhost out": true,
+		// "rabbitmq.connections running":                      true,
+		// "rabbitmq.channels running":                         true,
+		// "rabbitmq.channel.unacknowledged_messages channel user vhost": true,
+		// "rabbitmq.channel.prefetch channel user vhost":              true,
 	}, exists)
 }
@@ -41,27 +41,120 @@ func (m *metricImpl) Init(metric pdata.Metric) {
 }
 
 type metricStruct struct {
-	RabbitmqConsumers    MetricIntf
-	RabbitmqDeliveryRate MetricIntf
-	RabbitmqNumMessages  MetricIntf
-	RabbitmqPublishRate  MetricIntf
+	RabbitmqChannelPrefetch               MetricIntf
+	RabbitmqChannelUnacknowledgedMessages MetricIntf
+	RabbitmqChannels                      MetricIntf
+	RabbitmqClusterMessageRate            MetricIntf
+	RabbitmqClusterMessages               MetricIntf
+	RabbitmqClusterObjects                MetricIntf
+	RabbitmqConnections                   MetricIntf
+	RabbitmqConsumerUtilisation           MetricIntf
+	RabbitmqConsumers                     MetricIntf
+	RabbitmqDeliveryRate                  MetricIntf
+	RabbitmqExchangePublishRate           MetricIntf
+	RabbitmqMessageAcknowledged           MetricIntf
+	RabbitmqMessageDelivered              MetricIntf
+	RabbitmqMessageDropped                MetricIntf
+	RabbitmqMessagePublished              MetricIntf
+	RabbitmqMessageRedelivered            MetricIntf
+	RabbitmqNodeAlarm                     MetricIntf
+	RabbitmqNodeDiskFree                  MetricIntf
+	RabbitmqNodeDiskFreeLimit             MetricIntf
+	RabbitmqNodeErlangProcessesLimit      MetricIntf
+	RabbitmqNodeErlangProcessesUsed       MetricIntf
+	RabbitmqNodeFileDescriptorsLimit      MetricIntf
+	RabbitmqNodeFileDescriptorsUsed       MetricIntf
+	RabbitmqNodeMemoryLimit               MetricIntf
+	RabbitmqNodeMemoryUsed                MetricIntf
+	RabbitmqNodeRunQueue                  MetricIntf
+	RabbitmqNodeSocketsLimit              MetricIntf
+	RabbitmqNodeSocketsUsed               MetricIntf
+	RabbitmqNumMessages                   MetricIntf
+	RabbitmqPublishRate                   MetricIntf
+	RabbitmqQueueMembers                  MetricIntf
+	RabbitmqQueueMemory                   MetricIntf
+	RabbitmqQueueMessageBytes             MetricIntf
+	RabbitmqQueueOnlineMembers            MetricIntf
+	RabbitmqQueueRaftTerm                 MetricIntf
 }
 
 // Names returns a list of all the metric name strings.
 func (m *metricStruct) Names() []string {
 	return []string{
+		"rabbitmq.channel.prefetch",
+		"rabbitmq.channel.unacknowledged_messages",
+		"rabbitmq.channels",
+		"rabbitmq.cluster.message_rate",
+		"rabbitmq.cluster.messages",
+		"rabbitmq.cluster.objects",
+		"rabbitmq.connections",
+		"rabbitmq.consumer_utilisation",
 		"rabbitmq.consumers",
 		"rabbitmq.delivery_rate",
+		"rabbitmq.exchange.publish_rate",
+		"rabbitmq.message.acknowledged",
+		"rabbitmq.message.delivered",
+		"rabbitmq.message.dropped",
+		"rabbitmq.message.published",
+		"rabbitmq.message.redelivered",
+		"rabbitmq.node.alarm",
+		"rabbitmq.node.disk.free",
+		"rabbitmq.node.disk.free_limit",
+		"rabbitmq.node.erlang_processes.limit",
+		"rabbitmq.node.erlang_processes.used",
+		"rabbitmq.node.file_descriptors.limit",
+		"rabbitmq.node.file_descriptors.used",
+		"rabbitmq.node.memory.limit",
+		"rabbitmq.node.memory.used",
+		"rabbitmq.node.run_queue",
+		"rabbitmq.node.sockets.limit",
+		"rabbitmq.node.sockets.used",
 		"rabbitmq.num_messages",
 		"rabbitmq.publish_rate",
+		"rabbitmq.queue.members",
+		"rabbitmq.queue.memory",
+		"rabbitmq.queue.message_bytes",
+		"rabbitmq.queue.online_members",
+		"rabbitmq.queue.raft_term",
 	}
 }
 
 var metricsByName = map[string]MetricIntf{
-	"rabbitmq.consumers":     Metrics.RabbitmqConsumers,
-	"rabbitmq.delivery_rate": Metrics.RabbitmqDeliveryRate,
-	"rabbitmq.num_messages":  Metrics.RabbitmqNumMessages,
-	"rabbitmq.publish_rate":  Metrics.RabbitmqPublishRate,
+	"rabbitmq.channel.prefetch":                Metrics.RabbitmqChannelPrefetch,
+	"rabbitmq.channel.unacknowledged_messages": Metrics.RabbitmqChannelUnacknowledgedMessages,
+	"rabbitmq.channels":                        Metrics.RabbitmqChannels,
+	"rabbitmq.cluster.message_rate":            Metrics.RabbitmqClusterMessageRate,
+	"rabbitmq.cluster.messages":                Metrics.RabbitmqClusterMessages,
+	"rabbitmq.cluster.objects":                 Metrics.RabbitmqClusterObjects,
+	"rabbitmq.connections":                     Metrics.RabbitmqConnections,
+	"rabbitmq.consumer_utilisation":            Metrics.RabbitmqConsumerUtilisation,
+	"rabbitmq.consumers":                       Metrics.RabbitmqConsumers,
+	"rabbitmq.delivery_rate":                   Metrics.RabbitmqDeliveryRate,
+	"rabbitmq.exchange.publish_rate":           Metrics.RabbitmqExchangePublishRate,
+	"rabbitmq.message.acknowledged":            Metrics.RabbitmqMessageAcknowledged,
+	"rabbitmq.message.delivered":               Metrics.RabbitmqMessageDelivered,
+	"rabbitmq.message.dropped":                 Metrics.RabbitmqMessageDropped,
+	"rabbitmq.message.published":               Metrics.RabbitmqMessagePublished,
+	"rabbitmq.message.redelivered":             Metrics.RabbitmqMessageRedelivered,
+	"rabbitmq.node.alarm":                      Metrics.RabbitmqNodeAlarm,
+	"rabbitmq.node.disk.free":                  Metrics.RabbitmqNodeDiskFree,
+	"rabbitmq.node.disk.free_limit":            Metrics.RabbitmqNodeDiskFreeLimit,
+	"rabbitmq.node.erlang_processes.limit":     Metrics.RabbitmqNodeErlangProcessesLimit,
+	"rabbitmq.node.erlang_processes.used":      Metrics.RabbitmqNodeErlangProcessesUsed,
+	"rabbitmq.node.file_descriptors.limit":     Metrics.RabbitmqNodeFileDescriptorsLimit,
+	"rabbitmq.node.file_descriptors.used":      Metrics.RabbitmqNodeFileDescriptorsUsed,
+	"rabbitmq.node.memory.limit":               Metrics.RabbitmqNodeMemoryLimit,
+	"rabbitmq.node.memory.used":                Metrics.RabbitmqNodeMemoryUsed,
+	"rabbitmq.node.run_queue":                  Metrics.RabbitmqNodeRunQueue,
+	"rabbitmq.node.sockets.limit":              Metrics.RabbitmqNodeSocketsLimit,
+	"rabbitmq.node.sockets.used":               Metrics.RabbitmqNodeSocketsUsed,
+	"rabbitmq.num_messages":                    Metrics.RabbitmqNumMessages,
+	"rabbitmq.publish_rate":                    Metrics.RabbitmqPublishRate,
+	"rabbitmq.queue.members":                   Metrics.RabbitmqQueueMembers,
+	"rabbitmq.queue.memory":                    Metrics.RabbitmqQueueMemory,
+	"rabbitmq.queue.message_bytes":             Metrics.RabbitmqQueueMessageBytes,
+	"rabbitmq.queue.online_members":            Metrics.RabbitmqQueueOnlineMembers,
+	"rabbitmq.queue.raft_term":                 Metrics.RabbitmqQueueRaftTerm,
 }
 
 func (m *metricStruct) ByName(n string) MetricIntf {
@@ -71,6 +164,78 @@ func (m *metricStruct) ByName(n string) MetricIntf {
 // Metrics contains a set of methods for each metric that help with
 // manipulating those metrics.
 var Metrics = &metricStruct{
+	&metricImpl{
+		"rabbitmq.channel.prefetch",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.channel.prefetch")
+			metric.SetDescription("The maximum number of unacknowledged messages of each consumer on the channel, 0 if unlimited.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.channel.unacknowledged_messages",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.channel.unacknowledged_messages")
+			metric.SetDescription("The number of messages delivered on the channel but not yet acknowledged.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.channels",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.channels")
+			metric.SetDescription("The number of channels in each state.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.cluster.message_rate",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.cluster.message_rate")
+			metric.SetDescription("The rate (per second) at which messages are published, delivered, acknowledged and redelivered in the cluster.")
+			metric.SetUnit("1/s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.cluster.messages",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.cluster.messages")
+			metric.SetDescription("The number of messages in all queues of the cluster.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.cluster.objects",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.cluster.objects")
+			metric.SetDescription("The number of channels, connections, consumers, exchanges and queues in the cluster.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.connections",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.connections")
+			metric.SetDescription("The number of client connections in each state.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.consumer_utilisation",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.consumer_utilisation")
+			metric.SetDescription("The fraction of time that the queue is able to immediately deliver messages to consumers, reported while the queue has consumers.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"rabbitmq.consumers",
 		func(metric pdata.Metric) {
@@ -89,6 +254,178 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"rabbitmq.exchange.publish_rate",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.exchange.publish_rate")
+			metric.SetDescription("The rate (per second) at which messages are published to the exchange by clients and from the exchange to queues or other exchanges.")
+			metric.SetUnit("1/s")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.message.acknowledged",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.message.acknowledged")
+			metric.SetDescription("The number of messages acknowledged by consumers of the queue.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.message.delivered",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.message.delivered")
+			metric.SetDescription("The number of messages delivered or fetched from the queue, with or without acknowledgement.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.message.dropped",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.message.dropped")
+			metric.SetDescription("The number of messages dropped because they could not be routed to any queue and were not published as mandatory.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.message.published",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.message.published")
+			metric.SetDescription("The number of messages published to the queue.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.message.redelivered",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.message.redelivered")
+			metric.SetDescription("The number of messages redelivered from the queue.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.alarm",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.alarm")
+			metric.SetDescription("Whether the memory or disk alarm of the node is raised, 1 if raised and 0 otherwise. Publishers on all nodes are blocked while an alarm is raised.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.disk.free",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.disk.free")
+			metric.SetDescription("The free disk space on the partition of the node's data directory.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.disk.free_limit",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.disk.free_limit")
+			metric.SetDescription("The free disk space limit of the node, below which publishers are blocked.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.erlang_processes.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.erlang_processes.limit")
+			metric.SetDescription("The maximum number of Erlang processes of the node.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.erlang_processes.used",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.erlang_processes.used")
+			metric.SetDescription("The number of Erlang processes used by the node.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.file_descriptors.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.file_descriptors.limit")
+			metric.SetDescription("The number of file descriptors available to the node.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.file_descriptors.used",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.file_descriptors.used")
+			metric.SetDescription("The number of file descriptors used by the node.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.memory.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.memory.limit")
+			metric.SetDescription("The memory high watermark of the node, above which publishers are blocked.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.memory.used",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.memory.used")
+			metric.SetDescription("The memory used by the node.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.run_queue",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.run_queue")
+			metric.SetDescription("The average number of Erlang processes waiting to run on the node.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.sockets.limit",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.sockets.limit")
+			metric.SetDescription("The number of file descriptors the node can use as sockets.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.node.sockets.used",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.node.sockets.used")
+			metric.SetDescription("The number of file descriptors used by the node as sockets.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
 	&metricImpl{
 		"rabbitmq.num_messages",
 		func(metric pdata.Metric) {
@@ -107,6 +444,53 @@ var Metrics = &metricStruct{
 			metric.SetDataType(pdata.MetricDataTypeGauge)
 		},
 	},
+	&metricImpl{
+		"rabbitmq.queue.members",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.queue.members")
+			metric.SetDescription("The number of nodes hosting a replica of a quorum queue or stream. Only reported in management mode.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.queue.memory",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.queue.memory")
+			metric.SetDescription("The memory used by the queue process, including messages kept in memory.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.queue.message_bytes",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.queue.message_bytes")
+			metric.SetDescription("The size of the message bodies in the queue.")
+			metric.SetUnit("By")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.queue.online_members",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.queue.online_members")
+			metric.SetDescription("The number of nodes hosting a replica of a quorum queue or stream that are online. Only reported in management mode.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeGauge)
+		},
+	},
+	&metricImpl{
+		"rabbitmq.queue.raft_term",
+		func(metric pdata.Metric) {
+			metric.SetName("rabbitmq.queue.raft_term")
+			metric.SetDescription("The current Raft term of a quorum queue. The term increases with each leader election. Only reported in prometheus mode.")
+			metric.SetUnit("1")
+			metric.SetDataType(pdata.MetricDataTypeSum)
+			metric.Sum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		},
+	},
 }
 
 // M contains a set of methods for each metric that help with
@@ -115,14 +499,116 @@ var M = Metrics
 
 // Attributes contains the possible metric attributes that can be used.
 var Attributes = struct {
+	// AlarmType (The resource that the alarm is raised for.)
+	AlarmType string
+	// Channel (The channel name, made of the connection name and the channel number.)
+	Channel string
+	// ConnectionState (The state of the connection or channel, such as running, flow or blocked.)
+	ConnectionState string
+	// Direction (Whether messages are published to or from the exchange.)
+	Direction string
+	// Durability (Whether the queue survives a broker restart.)
+	Durability string
+	// Exchange (The exchange name.)
+	Exchange string
+	// Node (The name of the RabbitMQ node. The node of a quorum queue or stream is the node of its leader.)
+	Node string
+	// ObjectType (The type of object counted.)
+	ObjectType string
+	// Operation (The operation on messages.)
+	Operation string
 	// Queue (The rabbit queue name.)
 	Queue string
 	// State (The message state.)
 	State string
+	// Type (The type of the queue.)
+	Type string
+	// User (The user of the connection.)
+	User string
+	// Vhost (The virtual host.)
+	Vhost string
 }{
+	"alarm_type",
+	"channel",
+	"connection_state",
+	"direction",
+	"durability",
+	"exchange",
+	"node",
+	"object_type",
+	"operation",
 	"queue",
 	"state",
+	"type",
+	"user",
+	"vhost",
 }
 
 // A is an alias for Attributes.
 var A = Attributes
+
+// AttributeAlarmType are the possible values that the attribute "alarm_type" can have.
+var AttributeAlarmType = struct {
+	Memory string
+	Disk   string
+}{
+	"memory",
+	"disk",
+}
+
+// AttributeDirection are the possible values that the attribute "direction" can have.
+var AttributeDirection = struct {
+	In  string
+	Out string
+}{
+	"in",
+	"out",
+}
+
+// AttributeDurability are the possible values that the attribute "durability" can have.
+var AttributeDurability = struct {
+	Durable   string
+	Transient string
+}{
+	"durable",
+	"transient",
+}
+
+// AttributeObjectType are the possible values that the attribute "object_type" can have.
+var AttributeObjectType = struct {
+	Channel    string
+	Connection string
+	Consumer   string
+	Exchange   string
+	Queue      string
+}{
+	"channel",
+	"connection",
+	"consumer",
+	"exchange",
+	"queue",
+}
+
+// AttributeOperation are the possible values that the attribute "operation" can have.
+var AttributeOperation = struct {
+	Publish     string
+	Deliver     string
+	Acknowledge string
+	Redeliver   string
+}{
+	"publish",
+	"deliver",
+	"acknowledge",
+	"redeliver",
+}
+
+// AttributeType are the possible values that the attribute "type" can have.
+var AttributeType = struct {
+	Classic string
+	Quorum  string
+	Stream  string
+}{
+	"classic",
+	"quorum",
+	"stream",
+}
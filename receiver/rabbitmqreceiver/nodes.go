@@ -0,0 +1,100 @@
+package rabbitmqreceiver
+
+import (
+	"context"
+
+	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// nodeMetricsCount is the number of metrics missing if the node stats cannot be read.
+const nodeMetricsCount = 12
+
+// nodeAlarms maps the alarm fields of /api/nodes to the alarm_type attribute.
+var nodeAlarms = map[string]string{
+	"mem_alarm":       metadata.AttributeAlarmType.Memory,
+	"disk_free_alarm": metadata.AttributeAlarmType.Disk,
+}
+
+// scrapeNodes reports the resource usage, limits and alarms of each node from /api/nodes.
+func (r *rabbitmqScraper) scrapeNodes(ctx context.Context, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	var nodes []interface{}
+	if err := r.client.getJSON(ctx, "/api/nodes", &nodes); err != nil {
+		return err
+	}
+
+	nodeMetrics := []struct {
+		keys   []string
+		metric pdata.NumberDataPointSlice
+	}{
+		{[]string{"mem_used"}, initMetric(ms, metadata.M.RabbitmqNodeMemoryUsed).Gauge().DataPoints()},
+		{[]string{"mem_limit"}, initMetric(ms, metadata.M.RabbitmqNodeMemoryLimit).Gauge().DataPoints()},
+		{[]string{"disk_free"}, initMetric(ms, metadata.M.RabbitmqNodeDiskFree).Gauge().DataPoints()},
+		{[]string{"disk_free_limit"}, initMetric(ms, metadata.M.RabbitmqNodeDiskFreeLimit).Gauge().DataPoints()},
+		{[]string{"fd_used"}, initMetric(ms, metadata.M.RabbitmqNodeFileDescriptorsUsed).Gauge().DataPoints()},
+		{[]string{"fd_total"}, initMetric(ms, metadata.M.RabbitmqNodeFileDescriptorsLimit).Gauge().DataPoints()},
+		{[]string{"sockets_used"}, initMetric(ms, metadata.M.RabbitmqNodeSocketsUsed).Gauge().DataPoints()},
+		{[]string{"sockets_total"}, initMetric(ms, metadata.M.RabbitmqNodeSocketsLimit).Gauge().DataPoints()},
+		{[]string{"proc_used"}, initMetric(ms, metadata.M.RabbitmqNodeErlangProcessesUsed).Gauge().DataPoints()},
+		{[]string{"proc_total"}, initMetric(ms, metadata.M.RabbitmqNodeErlangProcessesLimit).Gauge().DataPoints()},
+		{[]string{"run_queue"}, initMetric(ms, metadata.M.RabbitmqNodeRunQueue).Gauge().DataPoints()},
+	}
+	alarmMetric := initMetric(ms, metadata.M.RabbitmqNodeAlarm).Gauge().DataPoints()
+
+	for _, v := range nodes {
+		node, ok := v.(map[string]interface{})
+		if !ok {
+			r.logger.Info("rabbitMQ api response format did not meet expectations")
+			break
+		}
+		attributes := pdata.NewAttributeMap()
+
+		nodeName, ok := node["name"].(string)
+		if !ok {
+			r.logger.Info("could not parse node name from body")
+			break
+		}
+		attributes.Upsert(metadata.A.Node, pdata.NewAttributeValueString(nodeName))
+
+		// nodes that are down only report their name
+		if running, _ := node["running"].(bool); !running {
+			r.logger.Info("node is not running", zap.String("node", nodeName))
+			continue
+		}
+
+		for _, nodeMetric := range nodeMetrics {
+			val, err := getValFromBody(nodeMetric.keys, node)
+			if err != nil {
+				r.logger.Info(
+					err.Error(),
+					zap.Strings("keys", nodeMetric.keys),
+				)
+				continue
+			}
+			addToDoubleMetric(nodeMetric.metric, attributes, val, now)
+		}
+
+		for field, alarmType := range nodeAlarms {
+			alarm, ok := node[field].(bool)
+			if !ok {
+				r.logger.Info(
+					"could not parse alarm from body",
+					zap.String("alarm", alarmType),
+				)
+				continue
+			}
+			attributes.Upsert(metadata.A.AlarmType, pdata.NewAttributeValueString(alarmType))
+			addToDoubleMetric(alarmMetric, attributes, boolToFloat(alarm), now)
+		}
+	}
+
+	return nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
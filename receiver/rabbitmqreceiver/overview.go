@@ -0,0 +1,92 @@
+package rabbitmqreceiver
+
+import (
+	"context"
+
+	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// overviewMetricsCount is the number of metrics missing if the overview cannot be read.
+const overviewMetricsCount = 4
+
+// overviewObjects maps the object totals of /api/overview to the object_type attribute.
+var overviewObjects = map[string]string{
+	"channels":    metadata.AttributeObjectType.Channel,
+	"connections": metadata.AttributeObjectType.Connection,
+	"consumers":   metadata.AttributeObjectType.Consumer,
+	"exchanges":   metadata.AttributeObjectType.Exchange,
+	"queues":      metadata.AttributeObjectType.Queue,
+}
+
+// overviewRates maps the message rates of /api/overview to the operation attribute.
+var overviewRates = map[string]string{
+	"publish_details":     metadata.AttributeOperation.Publish,
+	"deliver_get_details": metadata.AttributeOperation.Deliver,
+	"ack_details":         metadata.AttributeOperation.Acknowledge,
+	"redeliver_details":   metadata.AttributeOperation.Redeliver,
+}
+
+// scrapeOverview reports the cluster-wide totals from /api/overview, and adds the cluster name to the resource.
+func (r *rabbitmqScraper) scrapeOverview(ctx context.Context, resource pdata.Resource, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	var overview map[string]interface{}
+	if err := r.client.getJSON(ctx, "/api/overview", &overview); err != nil {
+		return err
+	}
+
+	if clusterName, ok := overview["cluster_name"].(string); ok {
+		resource.Attributes().UpsertString(clusterNameResourceAttribute, clusterName)
+	}
+
+	messagesMetric := initMetric(ms, metadata.M.RabbitmqClusterMessages).Gauge().DataPoints()
+	objectsMetric := initMetric(ms, metadata.M.RabbitmqClusterObjects).Gauge().DataPoints()
+	messageRateMetric := initMetric(ms, metadata.M.RabbitmqClusterMessageRate).Gauge().DataPoints()
+	droppedMetric := initMetric(ms, metadata.M.RabbitmqMessageDropped).Sum().DataPoints()
+
+	attributes := pdata.NewAttributeMap()
+	for field, state := range map[string]string{
+		"messages":                "total",
+		"messages_unacknowledged": "unacknowledged",
+		"messages_ready":          "ready",
+	} {
+		val, err := getValFromBody([]string{"queue_totals", field}, overview)
+		if err != nil {
+			r.logger.Info(
+				err.Error(),
+				zap.String("metric", "cluster.messages state:"+state),
+			)
+			continue
+		}
+		attributes.Upsert(metadata.A.State, pdata.NewAttributeValueString(state))
+		addToDoubleMetric(messagesMetric, attributes, val, now)
+	}
+
+	attributes = pdata.NewAttributeMap()
+	for field, objectType := range overviewObjects {
+		val, err := getValFromBody([]string{"object_totals", field}, overview)
+		if err != nil {
+			r.logger.Info(
+				err.Error(),
+				zap.String("metric", "cluster.objects object_type:"+objectType),
+			)
+			continue
+		}
+		attributes.Upsert(metadata.A.ObjectType, pdata.NewAttributeValueString(objectType))
+		addToDoubleMetric(objectsMetric, attributes, val, now)
+	}
+
+	// like the queue counters, the message stats are left out until the operation happened
+	attributes = pdata.NewAttributeMap()
+	for field, operation := range overviewRates {
+		if val, err := getValFromBody([]string{"message_stats", field, "rate"}, overview); err == nil {
+			attributes.Upsert(metadata.A.Operation, pdata.NewAttributeValueString(operation))
+			addToDoubleMetric(messageRateMetric, attributes, val, now)
+		}
+	}
+	if val, err := getValFromBody([]string{"message_stats", "drop_unroutable"}, overview); err == nil {
+		addToDoubleMetric(droppedMetric, pdata.NewAttributeMap(), val, now)
+	}
+
+	return nil
+}
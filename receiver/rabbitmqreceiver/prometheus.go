@@ -0,0 +1,263 @@
+package rabbitmqreceiver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// identityFamily labels the metrics of the rabbitmq_prometheus endpoint with the node that serves them, and its cluster.
+const identityFamily = "rabbitmq_identity_info"
+
+// perQueueFamilies are only reported per queue, since the totals of /metrics are meaningless for them.
+var perQueueFamilies = map[string]bool{
+	"rabbitmq_queue_consumer_utilisation": true,
+	"rabbitmq_raft_term_total":            true,
+}
+
+// queueKey identifies the queue of a sample. Both are empty for the node totals of /metrics.
+type queueKey struct {
+	vhost string
+	queue string
+}
+
+// scrapePrometheus reports the metrics of the node serving the rabbitmq_prometheus endpoint. Rates computed by
+// the management plugin, and the attributes of queues and connections only known to it, are not available.
+func (r *rabbitmqScraper) scrapePrometheus(ctx context.Context, resource pdata.Resource, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	body, err := r.client.get(ctx, r.cfg.Prometheus.Path)
+	if err != nil {
+		return err
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("could not parse prometheus metrics: %w", err)
+	}
+
+	nodeName := ""
+	if identity, ok := families[identityFamily]; ok && len(identity.GetMetric()) > 0 {
+		nodeName = labelValue(identity.GetMetric()[0], "rabbitmq_node")
+		if clusterName := labelValue(identity.GetMetric()[0], "rabbitmq_cluster"); clusterName != "" {
+			resource.Attributes().UpsertString(clusterNameResourceAttribute, clusterName)
+		}
+	}
+
+	r.scrapePrometheusQueues(ms, families, nodeName, now)
+	r.scrapePrometheusNodes(ms, families, nodeName, now)
+	if r.cfg.Connections.Enabled {
+		r.scrapePrometheusConnections(ms, families, now)
+	}
+	return nil
+}
+
+// scrapePrometheusQueues reports the queue metrics, summed per queue since counters are also kept per channel.
+// The members of quorum queues and streams are only known to the management API, while their Raft term is only
+// reported here.
+func (r *rabbitmqScraper) scrapePrometheusQueues(ms pdata.MetricSlice, families map[string]*dto.MetricFamily, nodeName string, now pdata.Timestamp) {
+	consumersMetric := initMetric(ms, metadata.M.RabbitmqConsumers).Gauge().DataPoints()
+	numMessagesMetric := initMetric(ms, metadata.M.RabbitmqNumMessages).Gauge().DataPoints()
+	messageBytesMetric := initMetric(ms, metadata.M.RabbitmqQueueMessageBytes).Gauge().DataPoints()
+	queueMetrics := []struct {
+		families []string
+		metric   pdata.NumberDataPointSlice
+		state    string
+	}{
+		{[]string{"rabbitmq_queue_consumers"}, consumersMetric, ""},
+		{[]string{"rabbitmq_queue_messages"}, numMessagesMetric, "total"},
+		{[]string{"rabbitmq_queue_messages_unacked"}, numMessagesMetric, "unacknowledged"},
+		{[]string{"rabbitmq_queue_messages_ready"}, numMessagesMetric, "ready"},
+		{[]string{"rabbitmq_queue_consumer_utilisation"}, initMetric(ms, metadata.M.RabbitmqConsumerUtilisation).Gauge().DataPoints(), ""},
+		{[]string{"rabbitmq_queue_process_memory_bytes"}, initMetric(ms, metadata.M.RabbitmqQueueMemory).Gauge().DataPoints(), ""},
+		{[]string{"rabbitmq_queue_messages_bytes"}, messageBytesMetric, "total"},
+		{[]string{"rabbitmq_queue_messages_unacked_bytes"}, messageBytesMetric, "unacknowledged"},
+		{[]string{"rabbitmq_queue_messages_ready_bytes"}, messageBytesMetric, "ready"},
+		{[]string{"rabbitmq_raft_term_total"}, initMetric(ms, metadata.M.RabbitmqQueueRaftTerm).Sum().DataPoints(), ""},
+		{[]string{"rabbitmq_queue_messages_published_total"}, initMetric(ms, metadata.M.RabbitmqMessagePublished).Sum().DataPoints(), ""},
+		{
+			// deliver_get of the management API is the sum of the deliveries and gets, with and without acknowledgement
+			[]string{"rabbitmq_queue_messages_delivered_total", "rabbitmq_queue_messages_delivered_ack_total", "rabbitmq_queue_get_total", "rabbitmq_queue_get_ack_total"},
+			initMetric(ms, metadata.M.RabbitmqMessageDelivered).Sum().DataPoints(),
+			"",
+		},
+		{[]string{"rabbitmq_queue_messages_acked_total"}, initMetric(ms, metadata.M.RabbitmqMessageAcknowledged).Sum().DataPoints(), ""},
+		{[]string{"rabbitmq_queue_messages_redelivered_total"}, initMetric(ms, metadata.M.RabbitmqMessageRedelivered).Sum().DataPoints(), ""},
+	}
+	droppedMetric := initMetric(ms, metadata.M.RabbitmqMessageDropped).Sum().DataPoints()
+
+	for _, queueMetric := range queueMetrics {
+		for key, val := range r.sumByQueue(families, queueMetric.families) {
+			attributes := pdata.NewAttributeMap()
+			if key.queue != "" {
+				attributes.Upsert(metadata.A.Queue, pdata.NewAttributeValueString(key.queue))
+				attributes.Upsert(metadata.A.Vhost, pdata.NewAttributeValueString(key.vhost))
+			}
+			if nodeName != "" {
+				attributes.Upsert(metadata.A.Node, pdata.NewAttributeValueString(nodeName))
+			}
+			if queueMetric.state != "" {
+				attributes.Upsert(metadata.A.State, pdata.NewAttributeValueString(queueMetric.state))
+			}
+			addToDoubleMetric(queueMetric.metric, attributes, val, now)
+		}
+	}
+
+	if family, ok := families["rabbitmq_channel_messages_unroutable_dropped_total"]; ok {
+		addToDoubleMetric(droppedMetric, pdata.NewAttributeMap(), sumSamples(family.GetMetric()), now)
+	}
+}
+
+// sumByQueue sums the samples of the families per queue, leaving out the queues that are not selected.
+func (r *rabbitmqScraper) sumByQueue(families map[string]*dto.MetricFamily, names []string) map[queueKey]float64 {
+	values := map[queueKey]float64{}
+	for _, name := range names {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			key := queueKey{queue: labelValue(m, "queue")}
+			// the samples kept per channel label the vhost of the queue as queue_vhost
+			if key.vhost = labelValue(m, "queue_vhost"); key.vhost == "" {
+				key.vhost = labelValue(m, "vhost")
+			}
+			if key.queue == "" && perQueueFamilies[name] {
+				continue
+			}
+			if key.queue != "" && !r.selectsQueue(key) {
+				continue
+			}
+			values[key] += sampleValue(m)
+		}
+	}
+	return values
+}
+
+// selectsQueue reports whether the queue is in the configured vhosts and selected by the queue filters.
+func (r *rabbitmqScraper) selectsQueue(key queueKey) bool {
+	if len(r.cfg.Vhosts) > 0 {
+		found := false
+		for _, vhost := range r.cfg.Vhosts {
+			found = found || vhost == key.vhost
+		}
+		if !found {
+			return false
+		}
+	}
+	return r.queueFilter.matches(key.queue)
+}
+
+// prometheusNodeMetrics maps the node families to the node metrics.
+var prometheusNodeMetrics = []struct {
+	family string
+	metric metadata.MetricIntf
+}{
+	{"rabbitmq_process_resident_memory_bytes", metadata.M.RabbitmqNodeMemoryUsed},
+	{"rabbitmq_resident_memory_limit_bytes", metadata.M.RabbitmqNodeMemoryLimit},
+	{"rabbitmq_disk_space_available_bytes", metadata.M.RabbitmqNodeDiskFree},
+	{"rabbitmq_disk_space_available_limit_bytes", metadata.M.RabbitmqNodeDiskFreeLimit},
+	{"rabbitmq_process_open_fds", metadata.M.RabbitmqNodeFileDescriptorsUsed},
+	{"rabbitmq_process_max_fds", metadata.M.RabbitmqNodeFileDescriptorsLimit},
+	{"rabbitmq_process_open_tcp_sockets", metadata.M.RabbitmqNodeSocketsUsed},
+	{"rabbitmq_process_max_tcp_sockets", metadata.M.RabbitmqNodeSocketsLimit},
+	{"rabbitmq_erlang_processes_used", metadata.M.RabbitmqNodeErlangProcessesUsed},
+	{"rabbitmq_erlang_processes_limit", metadata.M.RabbitmqNodeErlangProcessesLimit},
+	{"rabbitmq_erlang_scheduler_run_queue", metadata.M.RabbitmqNodeRunQueue},
+}
+
+// prometheusNodeAlarms maps the alarm families to the alarm_type attribute.
+var prometheusNodeAlarms = map[string]string{
+	"rabbitmq_alarms_memory_used_watermark":     metadata.AttributeAlarmType.Memory,
+	"rabbitmq_alarms_free_disk_space_watermark": metadata.AttributeAlarmType.Disk,
+}
+
+// scrapePrometheusNodes reports the resource usage, limits and alarms of the node.
+func (r *rabbitmqScraper) scrapePrometheusNodes(ms pdata.MetricSlice, families map[string]*dto.MetricFamily, nodeName string, now pdata.Timestamp) {
+	attributes := pdata.NewAttributeMap()
+	if nodeName != "" {
+		attributes.Upsert(metadata.A.Node, pdata.NewAttributeValueString(nodeName))
+	}
+
+	for _, nodeMetric := range prometheusNodeMetrics {
+		metric := initMetric(ms, nodeMetric.metric).Gauge().DataPoints()
+		if family, ok := families[nodeMetric.family]; ok {
+			addToDoubleMetric(metric, attributes, sumSamples(family.GetMetric()), now)
+		}
+	}
+
+	alarmMetric := initMetric(ms, metadata.M.RabbitmqNodeAlarm).Gauge().DataPoints()
+	for name, alarmType := range prometheusNodeAlarms {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+		attributes.Upsert(metadata.A.AlarmType, pdata.NewAttributeValueString(alarmType))
+		addToDoubleMetric(alarmMetric, attributes, sumSamples(family.GetMetric()), now)
+	}
+}
+
+// scrapePrometheusConnections reports the number of connections and channels of the node, and the
+// unacknowledged messages and prefetch of each channel. The state of connections and channels is not available.
+func (r *rabbitmqScraper) scrapePrometheusConnections(ms pdata.MetricSlice, families map[string]*dto.MetricFamily, now pdata.Timestamp) {
+	connectionsMetric := initMetric(ms, metadata.M.RabbitmqConnections).Gauge().DataPoints()
+	if family, ok := families["rabbitmq_connections"]; ok {
+		addToDoubleMetric(connectionsMetric, pdata.NewAttributeMap(), sumSamples(family.GetMetric()), now)
+	}
+	channelsMetric := initMetric(ms, metadata.M.RabbitmqChannels).Gauge().DataPoints()
+	if family, ok := families["rabbitmq_channels"]; ok {
+		addToDoubleMetric(channelsMetric, pdata.NewAttributeMap(), sumSamples(family.GetMetric()), now)
+	}
+
+	for _, channelMetric := range []struct {
+		family string
+		metric pdata.NumberDataPointSlice
+	}{
+		{"rabbitmq_channel_messages_unacked", initMetric(ms, metadata.M.RabbitmqChannelUnacknowledgedMessages).Gauge().DataPoints()},
+		{"rabbitmq_channel_prefetch", initMetric(ms, metadata.M.RabbitmqChannelPrefetch).Gauge().DataPoints()},
+	} {
+		family, ok := families[channelMetric.family]
+		if !ok {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			attributes := pdata.NewAttributeMap()
+			if channel := labelValue(m, "channel"); channel != "" {
+				attributes.Upsert(metadata.A.Channel, pdata.NewAttributeValueString(channel))
+			}
+			addToDoubleMetric(channelMetric.metric, attributes, sampleValue(m), now)
+		}
+	}
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, label := range m.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// sampleValue returns the value of a gauge, counter or untyped sample.
+func sampleValue(m *dto.Metric) float64 {
+	switch {
+	case m.Gauge != nil:
+		return m.GetGauge().GetValue()
+	case m.Counter != nil:
+		return m.GetCounter().GetValue()
+	default:
+		return m.GetUntyped().GetValue()
+	}
+}
+
+func sumSamples(metrics []*dto.Metric) float64 {
+	var sum float64
+	for _, m := range metrics {
+		sum += sampleValue(m)
+	}
+	return sum
+}
@@ -2,24 +2,25 @@ package rabbitmqreceiver
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 )
 
+// clusterNameResourceAttribute is the name of the cluster reporting the metrics.
+const clusterNameResourceAttribute = "rabbitmq.cluster.name"
+
 type rabbitmqScraper struct {
-	httpClient *http.Client
-	logger     *zap.Logger
-	cfg        *Config
+	client      *rabbitmqClient
+	logger      *zap.Logger
+	cfg         *Config
+	queueFilter *queueFilter
 }
 
 func newRabbitMQScraper(
@@ -33,54 +34,98 @@ func newRabbitMQScraper(
 }
 
 func (r *rabbitmqScraper) start(_ context.Context, host component.Host) error {
-	httpClient, err := r.cfg.ToClient(host.GetExtensions())
+	client, err := newRabbitMQClient(host, r.cfg, r.logger)
 	if err != nil {
 		return err
 	}
-	r.httpClient = httpClient
-	return nil
+	r.client = client
+	r.queueFilter, err = r.cfg.Queues.filter()
+	return err
 }
 
-func basicAuth(username, password string) string {
-	auth := username + ":" + password
-	return base64.StdEncoding.EncodeToString([]byte(auth))
-}
+func (r *rabbitmqScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	rms := pdata.NewMetrics()
+	rm := rms.ResourceMetrics().AppendEmpty()
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/rabbitmq")
+	now := pdata.NewTimestampFromTime(time.Now())
 
-func (r *rabbitmqScraper) scrape(context.Context) (pdata.Metrics, error) {
-	req, err := http.NewRequest("GET", r.cfg.Endpoint+"/api/queues", nil)
-	if err != nil {
-		return pdata.Metrics{}, err
+	if r.cfg.Mode == modePrometheus {
+		if err := r.scrapePrometheus(ctx, rm.Resource(), ilm.Metrics(), now); err != nil {
+			return pdata.Metrics{}, err
+		}
+		return rms, nil
 	}
 
-	req.Header.Add("Authorization", "Basic "+basicAuth(r.cfg.Username, r.cfg.Password))
-	resp, err := r.httpClient.Do(req)
-	if err != nil {
+	if err := r.scrapeQueues(ctx, ilm.Metrics(), now); err != nil {
 		return pdata.Metrics{}, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return pdata.Metrics{}, err
+	var errs scrapererror.ScrapeErrors
+	if err := r.scrapeOverview(ctx, rm.Resource(), ilm.Metrics(), now); err != nil {
+		errs.AddPartial(overviewMetricsCount, err)
+	}
+	// node stats require the monitoring tag, so failing to read them leaves the queue metrics in place
+	if err := r.scrapeNodes(ctx, ilm.Metrics(), now); err != nil {
+		errs.AddPartial(nodeMetricsCount, err)
+	}
+	if r.cfg.Exchanges.Enabled {
+		if err := r.scrapeExchanges(ctx, ilm.Metrics(), now); err != nil {
+			errs.AddPartial(exchangeMetricsCount, err)
+		}
+	}
+	if r.cfg.Connections.Enabled {
+		if err := r.scrapeConnections(ctx, ilm.Metrics(), now); err != nil {
+			errs.AddPartial(connectionMetricsCount, err)
+		}
+		if err := r.scrapeChannels(ctx, ilm.Metrics(), now); err != nil {
+			errs.AddPartial(channelMetricsCount, err)
+		}
 	}
 
-	var bodyParsed []interface{}
+	return rms, errs.Combine()
+}
 
-	err = json.Unmarshal(body, &bodyParsed)
+// scrapeQueues reports the messages, consumers and message rates of each queue from /api/queues.
+func (r *rabbitmqScraper) scrapeQueues(ctx context.Context, ms pdata.MetricSlice, now pdata.Timestamp) error {
+	queues, err := r.client.getVhostPages(ctx, "/api/queues")
 	if err != nil {
-		return pdata.Metrics{}, err
+		return err
 	}
-	rms := pdata.NewMetrics()
-	ilm := rms.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
-	ilm.InstrumentationLibrary().SetName("otelcol/rabbitmq")
-	now := pdata.NewTimestampFromTime(time.Now())
 
-	publishRateMetric := initMetric(ilm.Metrics(), metadata.M.RabbitmqPublishRate).Gauge().DataPoints()
-	deliveryRateMetric := initMetric(ilm.Metrics(), metadata.M.RabbitmqDeliveryRate).Gauge().DataPoints()
-	consumersMetric := initMetric(ilm.Metrics(), metadata.M.RabbitmqConsumers).Gauge().DataPoints()
-	numMessagesMetric := initMetric(ilm.Metrics(), metadata.M.RabbitmqNumMessages).Gauge().DataPoints()
+	publishRateMetric := initMetric(ms, metadata.M.RabbitmqPublishRate).Gauge().DataPoints()
+	deliveryRateMetric := initMetric(ms, metadata.M.RabbitmqDeliveryRate).Gauge().DataPoints()
+	messageCounters := []struct {
+		keys   []string
+		metric pdata.NumberDataPointSlice
+	}{
+		{[]string{"message_stats", "publish"}, initMetric(ms, metadata.M.RabbitmqMessagePublished).Sum().DataPoints()},
+		{[]string{"message_stats", "deliver_get"}, initMetric(ms, metadata.M.RabbitmqMessageDelivered).Sum().DataPoints()},
+		{[]string{"message_stats", "ack"}, initMetric(ms, metadata.M.RabbitmqMessageAcknowledged).Sum().DataPoints()},
+		{[]string{"message_stats", "redeliver"}, initMetric(ms, metadata.M.RabbitmqMessageRedelivered).Sum().DataPoints()},
+	}
+	queueGauges := []struct {
+		keys   []string
+		metric pdata.NumberDataPointSlice
+	}{
+		{[]string{"consumer_utilisation"}, initMetric(ms, metadata.M.RabbitmqConsumerUtilisation).Gauge().DataPoints()},
+		{[]string{"memory"}, initMetric(ms, metadata.M.RabbitmqQueueMemory).Gauge().DataPoints()},
+	}
+	messageBytesMetric := initMetric(ms, metadata.M.RabbitmqQueueMessageBytes).Gauge().DataPoints()
+	messageBytes := []struct {
+		keys  []string
+		state string
+	}{
+		{[]string{"message_bytes"}, "total"},
+		{[]string{"message_bytes_unacknowledged"}, "unacknowledged"},
+		{[]string{"message_bytes_ready"}, "ready"},
+	}
+	membersMetric := initMetric(ms, metadata.M.RabbitmqQueueMembers).Gauge().DataPoints()
+	onlineMembersMetric := initMetric(ms, metadata.M.RabbitmqQueueOnlineMembers).Gauge().DataPoints()
+	consumersMetric := initMetric(ms, metadata.M.RabbitmqConsumers).Gauge().DataPoints()
+	numMessagesMetric := initMetric(ms, metadata.M.RabbitmqNumMessages).Gauge().DataPoints()
 
-	for _, v := range bodyParsed {
+	for _, v := range queues {
 		queue, ok := v.(map[string]interface{})
 		if !ok {
 			r.logger.Info("rabbitMQ api response format did not meet expectations")
@@ -93,7 +138,11 @@ func (r *rabbitmqScraper) scrape(context.Context) (pdata.Metrics, error) {
 			r.logger.Info("could not parse queue name from body")
 			break
 		}
+		if !r.queueFilter.matches(queueName) {
+			continue
+		}
 		attributes.Upsert(metadata.A.Queue, pdata.NewAttributeValueString(queueName))
+		addQueueAttributes(attributes, queue)
 
 		val, err := getValFromBody([]string{"message_stats", "publish_details", "rate"}, queue)
 		if err != nil {
@@ -115,6 +164,13 @@ func (r *rabbitmqScraper) scrape(context.Context) (pdata.Metrics, error) {
 			addToDoubleMetric(deliveryRateMetric, attributes, val, now)
 		}
 
+		// the management plugin leaves out the counters of operations that never happened on the queue
+		for _, counter := range messageCounters {
+			if val, err := getValFromBody(counter.keys, queue); err == nil {
+				addToDoubleMetric(counter.metric, attributes, val, now)
+			}
+		}
+
 		val, err = getValFromBody([]string{"consumers"}, queue)
 		if err != nil {
 			r.logger.Info(
@@ -125,6 +181,21 @@ func (r *rabbitmqScraper) scrape(context.Context) (pdata.Metrics, error) {
 			addToDoubleMetric(consumersMetric, attributes, val, now)
 		}
 
+		// consumer utilisation is only known while the queue has consumers
+		for _, queueGauge := range queueGauges {
+			if val, err := getValFromBody(queueGauge.keys, queue); err == nil {
+				addToDoubleMetric(queueGauge.metric, attributes, val, now)
+			}
+		}
+
+		// only quorum queues and streams are replicated
+		if members, ok := queue["members"].([]interface{}); ok {
+			addToDoubleMetric(membersMetric, attributes, float64(len(members)), now)
+		}
+		if online, ok := queue["online"].([]interface{}); ok {
+			addToDoubleMetric(onlineMembersMetric, attributes, float64(len(online)), now)
+		}
+
 		val, err = getValFromBody([]string{"messages"}, queue)
 		if err != nil {
 			r.logger.Info(
@@ -157,9 +228,46 @@ func (r *rabbitmqScraper) scrape(context.Context) (pdata.Metrics, error) {
 			attributes.Upsert(metadata.A.State, pdata.NewAttributeValueString("ready"))
 			addToDoubleMetric(numMessagesMetric, attributes, val, now)
 		}
+
+		for _, field := range messageBytes {
+			val, err := getValFromBody(field.keys, queue)
+			if err != nil {
+				r.logger.Info(
+					err.Error(),
+					zap.Strings("keys", field.keys),
+				)
+				continue
+			}
+			attributes.Upsert(metadata.A.State, pdata.NewAttributeValueString(field.state))
+			addToDoubleMetric(messageBytesMetric, attributes, val, now)
+		}
 	}
 
-	return rms, nil
+	return nil
+}
+
+// addQueueAttributes adds the vhost, node, type and durability of the queue to attributes.
+func addQueueAttributes(attributes pdata.AttributeMap, queue map[string]interface{}) {
+	for field, attribute := range map[string]string{
+		"vhost": metadata.A.Vhost,
+		"node":  metadata.A.Node,
+		"type":  metadata.A.Type,
+	} {
+		if value, ok := queue[field].(string); ok {
+			attributes.Upsert(attribute, pdata.NewAttributeValueString(value))
+		}
+	}
+	// quorum queues and streams are hosted by their leader
+	if leader, ok := queue["leader"].(string); ok {
+		attributes.Upsert(metadata.A.Node, pdata.NewAttributeValueString(leader))
+	}
+	if durable, ok := queue["durable"].(bool); ok {
+		durability := metadata.AttributeDurability.Transient
+		if durable {
+			durability = metadata.AttributeDurability.Durable
+		}
+		attributes.Upsert(metadata.A.Durability, pdata.NewAttributeValueString(durability))
+	}
 }
 
 func getValFromBody(keys []string, body map[string]interface{}) (float64, error) {
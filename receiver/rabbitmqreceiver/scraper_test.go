@@ -2,37 +2,38 @@ package rabbitmqreceiver
 
 import (
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/observiq/opentelemetry-components/receiver/helper"
+	"github.com/observiq/opentelemetry-components/receiver/rabbitmqreceiver/internal/metadata"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 	"go.uber.org/zap"
 )
 
 func TestScraper(t *testing.T) {
-	f, err := ioutil.ReadFile("./testdata/exampleAPICall.json")
-	require.NoError(t, err)
-	rabbitmqMock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-		if req.URL.Path == "/api/queues" {
-			rw.WriteHeader(200)
-			_, err = rw.Write(f)
-			require.NoError(t, err)
-			return
-		}
-		rw.WriteHeader(404)
-	}))
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/api/queues":   "./testdata/exampleAPICall.json",
+		"/api/nodes":    "./testdata/exampleNodesAPICall.json",
+		"/api/overview": "./testdata/exampleOverviewAPICall.json",
+	})
 	sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
 		HTTPClientSettings: confighttp.HTTPClientSettings{
 			Endpoint: rabbitmqMock.URL,
 		},
 		Username: "dev",
 		Password: "dev",
+		PageSize: 2,
 	})
 	require.NoError(t, err)
 	err = sc.start(context.Background(), componenttest.NewNopHost())
@@ -44,6 +45,285 @@ func TestScraper(t *testing.T) {
 	helper.ScraperTest(t, sc.scrape, expectedFileBytes)
 }
 
+func TestScraperExchangesAndConnections(t *testing.T) {
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/api/queues":      "./testdata/exampleAPICall.json",
+		"/api/nodes":       "./testdata/exampleNodesAPICall.json",
+		"/api/overview":    "./testdata/exampleOverviewAPICall.json",
+		"/api/exchanges":   "./testdata/exampleExchangesAPICall.json",
+		"/api/connections": "./testdata/exampleConnectionsAPICall.json",
+		"/api/channels":    "./testdata/exampleChannelsAPICall.json",
+	})
+	testCases := []struct {
+		desc        string
+		aggregateBy string
+	}{
+		{
+			desc:        "per channel",
+			aggregateBy: aggregateByChannel,
+		},
+		{
+			desc:        "per user",
+			aggregateBy: aggregateByUser,
+		},
+		{
+			desc:        "per vhost",
+			aggregateBy: aggregateByVhost,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: rabbitmqMock.URL,
+				},
+				Username:  "dev",
+				Password:  "dev",
+				PageSize:  2,
+				Exchanges: ExchangesConfig{Enabled: true},
+				Connections: ConnectionsConfig{
+					Enabled:     true,
+					AggregateBy: tC.aggregateBy,
+				},
+			})
+			require.NoError(t, err)
+			err = sc.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			expectedFileBytes, err := ioutil.ReadFile("./testdata/examplejsonmetrics/testscraperconnections/" + tC.aggregateBy + "/expected_metrics.json")
+			require.NoError(t, err)
+
+			helper.ScraperTest(t, sc.scrape, expectedFileBytes)
+		})
+	}
+}
+
+func TestScraperPrometheus(t *testing.T) {
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/metrics":            "./testdata/examplePrometheus.txt",
+		"/metrics/per-object": "./testdata/examplePrometheusPerObject.txt",
+	})
+	testCases := []struct {
+		desc     string
+		path     string
+		queues   QueuesConfig
+		expected string
+	}{
+		{
+			desc:     "per object",
+			path:     "/metrics/per-object",
+			queues:   QueuesConfig{Exclude: []string{`amq\.gen-.*`}},
+			expected: "./testdata/examplejsonmetrics/testscraperprometheus/perobject/expected_metrics.json",
+		},
+		{
+			desc:     "node totals",
+			path:     "/metrics",
+			expected: "./testdata/examplejsonmetrics/testscraperprometheus/totals/expected_metrics.json",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: rabbitmqMock.URL,
+				},
+				Mode:       modePrometheus,
+				Prometheus: PrometheusConfig{Path: tC.path},
+				Queues:     tC.queues,
+				Connections: ConnectionsConfig{
+					Enabled:     true,
+					AggregateBy: aggregateByChannel,
+				},
+			})
+			require.NoError(t, err)
+			err = sc.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			expectedFileBytes, err := ioutil.ReadFile(tC.expected)
+			require.NoError(t, err)
+
+			helper.ScraperTest(t, sc.scrape, expectedFileBytes)
+		})
+	}
+}
+
+func TestScraperPrometheusInvalid(t *testing.T) {
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/metrics": "./testdata/exampleAPICall.json",
+	})
+	sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: rabbitmqMock.URL,
+		},
+		Mode:       modePrometheus,
+		Prometheus: PrometheusConfig{Path: "/metrics"},
+	})
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	_, err = sc.scrape(context.Background())
+	require.Error(t, err)
+}
+
+func TestScraperQueueFilters(t *testing.T) {
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/api/queues":   "./testdata/exampleAPICall.json",
+		"/api/nodes":    "./testdata/exampleNodesAPICall.json",
+		"/api/overview": "./testdata/exampleOverviewAPICall.json",
+	})
+	testCases := []struct {
+		desc     string
+		vhosts   []string
+		queues   QueuesConfig
+		expected []string
+	}{
+		{
+			desc:     "all queues",
+			expected: []string{"dev/webq1", "shop/webq1", "shop/orders.stream", "dev/amq.gen-JzTY20BRgKO-HjmUJj0wLg"},
+		},
+		{
+			desc:     "vhosts",
+			vhosts:   []string{"shop"},
+			expected: []string{"shop/webq1", "shop/orders.stream"},
+		},
+		{
+			desc:     "include",
+			queues:   QueuesConfig{Include: []string{"webq[0-9]+", "orders"}},
+			expected: []string{"dev/webq1", "shop/webq1"},
+		},
+		{
+			desc:     "exclude",
+			queues:   QueuesConfig{Exclude: []string{`amq\.gen-.*`}},
+			expected: []string{"dev/webq1", "shop/webq1", "shop/orders.stream"},
+		},
+		{
+			desc:     "vhosts, include and exclude",
+			vhosts:   []string{"dev", "shop"},
+			queues:   QueuesConfig{Include: []string{"webq.*", "orders.*"}, Exclude: []string{".*stream"}},
+			expected: []string{"dev/webq1", "shop/webq1"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: rabbitmqMock.URL,
+				},
+				Username: "dev",
+				Password: "dev",
+				PageSize: 1,
+				Vhosts:   tC.vhosts,
+				Queues:   tC.queues,
+			})
+			require.NoError(t, err)
+			err = sc.start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+
+			md, err := sc.scrape(context.Background())
+			require.NoError(t, err)
+
+			queues := []string{}
+			metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+			for i := 0; i < metrics.Len(); i++ {
+				if metrics.At(i).Name() != metadata.M.RabbitmqConsumers.Name() {
+					continue
+				}
+				dps := metrics.At(i).Gauge().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					vhost, _ := dps.At(j).Attributes().Get(metadata.A.Vhost)
+					queue, _ := dps.At(j).Attributes().Get(metadata.A.Queue)
+					queues = append(queues, vhost.StringVal()+"/"+queue.StringVal())
+				}
+			}
+			require.ElementsMatch(t, tC.expected, queues)
+		})
+	}
+}
+
+func TestScraperNodesUnavailable(t *testing.T) {
+	rabbitmqMock := newMockServer(t, map[string]string{
+		"/api/queues": "./testdata/exampleAPICall.json",
+	})
+	sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: rabbitmqMock.URL,
+		},
+		Username: "dev",
+		Password: "dev",
+		PageSize: 2,
+	})
+	require.NoError(t, err)
+	err = sc.start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	md, err := sc.scrape(context.Background())
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	require.NotZero(t, md.MetricCount())
+}
+
+// newMockServer serves the files in responses by request path. Like the management API, lists are paginated
+// when a page is requested, and the list of a vhost is filtered from the list of all vhosts.
+func newMockServer(t *testing.T, responses map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		path, vhost := req.URL.EscapedPath(), ""
+		file, ok := responses[path]
+		if !ok {
+			i := strings.LastIndex(path, "/")
+			file, ok = responses[path[:i]]
+			vhost, _ = url.PathUnescape(path[i+1:])
+		}
+		if !ok {
+			rw.WriteHeader(404)
+			return
+		}
+		body, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		if vhost != "" || req.URL.Query().Get("page") != "" {
+			body = paginate(t, body, vhost, req.URL.Query())
+		}
+		rw.WriteHeader(200)
+		_, err = rw.Write(body)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// paginate returns the requested page of the items of list in vhost, or of all items if vhost is empty.
+func paginate(t *testing.T, list []byte, vhost string, query url.Values) []byte {
+	var items []interface{}
+	require.NoError(t, json.Unmarshal(list, &items))
+	if vhost != "" {
+		vhostItems := []interface{}{}
+		for _, item := range items {
+			if item.(map[string]interface{})["vhost"] == vhost {
+				vhostItems = append(vhostItems, item)
+			}
+		}
+		items = vhostItems
+	}
+
+	pageNumber, err := strconv.Atoi(query.Get("page"))
+	require.NoError(t, err)
+	pageSize, err := strconv.Atoi(query.Get("page_size"))
+	require.NoError(t, err)
+	start, end := (pageNumber-1)*pageSize, pageNumber*pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+
+	body, err := json.Marshal(page{
+		Items:     items[start:end],
+		PageCount: (len(items) + pageSize - 1) / pageSize,
+	})
+	require.NoError(t, err)
+	return body
+}
+
 func TestScraperFailedStart(t *testing.T) {
 	sc, err := newRabbitMQScraper(zap.NewNop(), &Config{
 		HTTPClientSettings: confighttp.HTTPClientSettings{
@@ -56,6 +336,7 @@ func TestScraperFailedStart(t *testing.T) {
 		},
 		Username: "dev",
 		Password: "dev",
+		PageSize: 2,
 	})
 	require.NoError(t, err)
 	err = sc.start(context.Background(), componenttest.NewNopHost())